@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// bench runs the benchmarks of the selected days and compares them with the baseline.
+func bench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	count := fs.Int("count", 5, "number of times to run each benchmark")
+	benchtime := fs.String("benchtime", "100ms", "run time or iterations of each benchmark, see go help testflag")
+	baseline := fs.String("baseline", aoc.BaselinePath, "path of the baseline")
+	save := fs.Bool("save", false, "save the results as the new baseline")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	days, err := selectDays(fs.Args())
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = fmt.Sprintf("%02d", day)
+	}
+
+	cmd := exec.Command("go", "test", "./days", "-run", "^$",
+		"-bench", fmt.Sprintf("^BenchmarkDays$/^day(%s)$", strings.Join(names, "|")),
+		"-benchmem", "-count", fmt.Sprint(*count), "-benchtime", *benchtime)
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = cmd.Start(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// show the progress while the benchmarks run
+	results, err := aoc.ParseBenchmarks(io.TeeReader(out, os.Stderr))
+	if werr := cmd.Wait(); err == nil {
+		err = werr
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if len(results.Results) == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "no benchmarks ran, are the inputs cached?")
+		return 1
+	}
+
+	old, err := aoc.LoadBaseline(*baseline)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		old = &aoc.Baseline{}
+		_, _ = fmt.Fprintf(os.Stderr, "no baseline at %s, save one with -save\n", *baseline)
+	case err != nil:
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Println()
+	if err = aoc.WriteComparisons(os.Stdout, aoc.Compare(old, results)); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *save {
+		results.Merge(old)
+		if err = results.Save(*baseline); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "saved the baseline to %s\n", *baseline)
+	}
+
+	return 0
+}
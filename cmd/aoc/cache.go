@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const cacheUsage = `Usage:
+	aoc cache list
+	aoc cache verify
+	aoc cache clear [-year n] [-all] [day ...]
+`
+
+// cache manages the cached puzzle inputs.
+func cache(args []string) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(os.Stderr, cacheUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return cacheList()
+	case "verify":
+		return cacheVerify()
+	case "clear":
+		return cacheClear(args[1:])
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "unknown cache command %q\n%s", args[0], cacheUsage)
+		return 2
+	}
+}
+
+// cacheList prints the cached inputs with their metadata.
+func cacheList() int {
+	entries, err := aoc.DefaultClient.Cache()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Year\tDay\tSize\tFetched\tSHA-256\tLayout\t")
+	for _, e := range entries {
+		layout := "current"
+		if e.Legacy {
+			layout = "legacy"
+		}
+
+		if e.Meta == nil {
+			_, _ = fmt.Fprintf(tw, "%d\t%d\t\t\t\t%s\t\n", e.Puzzle.Year, e.Puzzle.Day, layout)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%.12s\t%s\t\n", e.Puzzle.Year, e.Puzzle.Day, e.Meta.Size, e.Meta.Fetched.Local().Format(time.RFC3339), e.Meta.SHA256, layout)
+	}
+
+	if err = tw.Flush(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// cacheVerify checks every cached input against its metadata. It returns a non-zero exit code
+// when an input is corrupt, inputs without metadata are reported but accepted.
+func cacheVerify() int {
+	entries, err := aoc.DefaultClient.Cache()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var failed bool
+	for _, e := range entries {
+		if err = e.Verify(); err != nil {
+			fmt.Println(err)
+			failed = failed || !errors.Is(err, aoc.ErrNoMeta)
+			continue
+		}
+		fmt.Printf("%s: ok\n", e.Puzzle)
+	}
+
+	if failed {
+		_, _ = fmt.Fprintln(os.Stderr, "remove corrupt inputs with: aoc cache clear [-year n] day ...")
+		return 1
+	}
+	return 0
+}
+
+// cacheClear removes the cached inputs of the given days, or of all days with -all.
+func cacheClear(args []string) int {
+	fs := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	year := fs.Int("year", aoc.DefaultYear, "year of the days to clear")
+	all := fs.Bool("all", false, "clear all cached inputs")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var puzzles []aoc.Puzzle
+
+	switch {
+	case *all && fs.NArg() == 0:
+		entries, err := aoc.DefaultClient.Cache()
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		// ClearCache removes both layouts, so a day is cleared once
+		for i, e := range entries {
+			if i > 0 && e.Puzzle == entries[i-1].Puzzle {
+				continue
+			}
+			puzzles = append(puzzles, e.Puzzle)
+		}
+	case !*all && fs.NArg() > 0:
+		for _, arg := range fs.Args() {
+			day, err := strconv.Atoi(arg)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "invalid day %q\n", arg)
+				return 2
+			}
+			p := aoc.Puzzle{Year: *year, Day: day}
+			if err = p.Validate(); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+				return 2
+			}
+			puzzles = append(puzzles, p)
+		}
+	default:
+		_, _ = fmt.Fprint(os.Stderr, "give either the days to clear or -all\n", cacheUsage)
+		return 2
+	}
+
+	var failed bool
+	for _, p := range puzzles {
+		if err := aoc.DefaultClient.ClearCache(p); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			failed = failed || !errors.Is(err, aoc.ErrNotCached)
+			continue
+		}
+		fmt.Printf("%s: cleared\n", p)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"os"
+	"strings"
+)
+
+// leaderboard prints a report of a private leaderboard: the standings, the completion times of a day
+// with -day, or the differences between two members with -compare.
+func leaderboard(args []string) int {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	year := fs.Int("year", aoc.DefaultYear, "year of the event")
+	day := fs.Int("day", 0, "print the completion times of the day")
+	compare := fs.String("compare", "", "print the differences between two members, as name,name")
+	markdown := fs.Bool("markdown", false, "print the report as a Markdown table")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		_, _ = fmt.Fprintln(os.Stderr, "give the id of the leaderboard")
+		return 2
+	}
+
+	lb, err := aoc.DefaultClient.Leaderboard(context.Background(), *year, fs.Arg(0))
+	if errors.Is(err, aoc.ErrInvalidLeaderboard) {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var table aoc.Table
+
+	switch {
+	case *compare != "":
+		names := strings.Split(*compare, ",")
+		if len(names) != 2 {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid -compare %q: want two members\n", *compare)
+			return 2
+		}
+
+		var members [2]aoc.Member
+		for i, name := range names {
+			m, ok := lb.Member(name)
+			if !ok {
+				_, _ = fmt.Fprintf(os.Stderr, "unknown member %q\n", name)
+				return 1
+			}
+			members[i] = m
+		}
+
+		table = lb.DeltaTable(members[0], members[1])
+	case *day != 0:
+		if err = (aoc.Puzzle{Year: *year, Day: *day}).Validate(); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		table = lb.DayTable(*day)
+	default:
+		table = lb.StandingsTable()
+	}
+
+	write := table.WriteText
+	if *markdown {
+		write = table.WriteMarkdown
+	}
+
+	if err = write(os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
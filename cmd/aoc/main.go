@@ -0,0 +1,57 @@
+// Command aoc runs the solutions of the Advent of Code 2022 puzzles.
+//
+// Usage:
+//
+//	aoc run [-part n] [-json] [-record] [-render] [day ...]
+//	aoc cache list|verify|clear
+//	aoc prefetch [-year n] [-workers n]
+//	aoc leaderboard [-year n] [-day n] [-compare name,name] [-markdown] id
+//	aoc new [-offline] day
+//	aoc bench [-count n] [-benchtime d] [-baseline path] [-save] [day ...]
+//
+// Without days, all days are run.
+package main
+
+import (
+	"fmt"
+	_ "github.com/pimvanhespen/aoc2022/days"
+	"os"
+)
+
+const usage = `Usage:
+	aoc run [-part n] [-json] [-record] [-render] [day ...]
+	aoc cache list|verify|clear
+	aoc prefetch [-year n] [-workers n]
+	aoc leaderboard [-year n] [-day n] [-compare name,name] [-markdown] id
+	aoc new [-offline] day
+	aoc bench [-count n] [-benchtime d] [-baseline path] [-save] [day ...]
+`
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "run":
+		return runDays(args[1:])
+	case "cache":
+		return cache(args[1:])
+	case "prefetch":
+		return prefetch(args[1:])
+	case "leaderboard":
+		return leaderboard(args[1:])
+	case "new":
+		return newDay(args[1:])
+	case "bench":
+		return bench(args[1:])
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	modulePath   = "github.com/pimvanhespen/aoc2022"
+	daysFile     = "days/days.go"
+	dayDirFormat = "days/%02d"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// scaffoldFiles maps the files of a new day to their templates, files without a template are created empty.
+// XX in a file name is replaced by the day, e.g. day05.go.
+var scaffoldFiles = map[string]string{
+	"dayXX.go":             "day.go.tmpl",
+	"dayXX_test.go":        "day_test.go.tmpl",
+	"testdata/example.txt": "",
+}
+
+// newDay creates the skeleton of the solution of a day, with the examples of the puzzle page as fixtures.
+func newDay(args []string) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	offline := fs.Bool("offline", false, "do not download the examples of the puzzle")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(os.Stderr, "Usage:\n\taoc new [-offline] day\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	day, err := strconv.Atoi(fs.Arg(0))
+	if err == nil {
+		err = aoc.Day(day).Validate()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid day %q: %v\n", fs.Arg(0), err)
+		return 2
+	}
+
+	created, err := scaffold(".", day)
+	for _, fp := range created {
+		fmt.Println("created", fp)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	dir := fmt.Sprintf(dayDirFormat, day)
+
+	if !*offline {
+		n, err := fetchExamples(aoc.DefaultClient, ".", day)
+		switch {
+		case err != nil:
+			// the day is usable without the examples, they can be pasted by hand
+			_, _ = fmt.Fprintf(os.Stderr, "no examples downloaded: %v\n", err)
+		case n > 0:
+			fmt.Printf("wrote the examples of %d parts to %s, run: go test ./%s\n", n, filepath.Join(dir, "testdata"), dir)
+			return 0
+		}
+	}
+
+	fmt.Printf("paste the example into %s, the answers into %s and run: go test ./%s\n",
+		filepath.Join(dir, "testdata", "example.txt"), filepath.Join(dir, "testdata", "answers.txt"), dir)
+	return 0
+}
+
+// fetchExamples writes the examples and answers on the puzzle page of the day to the testdata directory of the day
+// in root. It returns the number of parts with an example.
+func fetchExamples(c *aoc.Client, root string, day int) (int, error) {
+	page, err := c.Page(aoc.Day(day))
+	if err != nil {
+		return 0, err
+	}
+
+	fixtures := page.Fixtures()
+	if len(fixtures) == 0 {
+		return 0, nil
+	}
+
+	dir := filepath.Join(root, fmt.Sprintf(dayDirFormat, day), "testdata")
+	return len(fixtures), aoc.WriteFixtures(dir, fixtures)
+}
+
+// scaffold creates the files of the day in root and registers the day in days/days.go.
+// It does not create any file when one of them already exists. It returns the created files.
+func scaffold(root string, day int) ([]string, error) {
+	dir := filepath.Join(root, fmt.Sprintf(dayDirFormat, day))
+
+	names := make([]string, 0, len(scaffoldFiles))
+	for name := range scaffoldFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contents := make(map[string][]byte, len(names))
+	for _, name := range names {
+		fp := filepath.Join(dir, filepath.FromSlash(scaffoldName(name, day)))
+		if _, err := os.Stat(fp); !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("refusing to overwrite %s", fp)
+		}
+
+		b, err := render(scaffoldFiles[name], day)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", name, err)
+		}
+		contents[name] = b
+	}
+
+	var created []string
+
+	for _, name := range names {
+		fp := filepath.Join(dir, filepath.FromSlash(scaffoldName(name, day)))
+		if err := createFile(fp, contents[name]); err != nil {
+			return created, err
+		}
+		created = append(created, fp)
+	}
+
+	return created, registerDay(filepath.Join(root, filepath.FromSlash(daysFile)), day)
+}
+
+// scaffoldName returns the name of a scaffold file for the day.
+func scaffoldName(name string, day int) string {
+	return strings.ReplaceAll(name, "XX", fmt.Sprintf("%02d", day))
+}
+
+// render executes the template of a file, the Go source is formatted.
+func render(name string, day int) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Day int
+		Pkg string
+	}{day, fmt.Sprintf("%02d", day)}
+
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(name, ".go.tmpl") {
+		return format.Source(buf.Bytes())
+	}
+	return buf.Bytes(), nil
+}
+
+// createFile creates a new file, it fails when the file exists.
+func createFile(path string, b []byte) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cerr := f.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	_, err = f.Write(b)
+	return err
+}
+
+// registerDay adds the import of the day to the imports of the days package, in order,
+// so the runner picks it up.
+func registerDay(path string, day int) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	imp := fmt.Sprintf("\t_ %q\n", modulePath+"/"+fmt.Sprintf(dayDirFormat, day))
+
+	src := string(b)
+	if strings.Contains(src, imp) {
+		return nil
+	}
+
+	start := strings.Index(src, "import (\n")
+	end := strings.Index(src, "\n)")
+	if start < 0 || end < start {
+		return fmt.Errorf("no import block in %s", path)
+	}
+	start += len("import (\n")
+
+	lines := strings.SplitAfter(src[start:end+1], "\n")
+	lines = append(lines[:len(lines)-1], imp)
+	sort.Strings(lines)
+
+	out, err := format.Source([]byte(src[:start] + strings.Join(lines, "") + src[end+1:]))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, out, 0644)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	root := t.TempDir()
+
+	days := filepath.Join(root, "days", "days.go")
+	if err := os.MkdirAll(filepath.Dir(days), 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := "package days\n\nimport (\n\t_ \"github.com/pimvanhespen/aoc2022/days/01\"\n\t_ \"github.com/pimvanhespen/aoc2022/days/03\"\n)\n"
+	if err := os.WriteFile(days, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := scaffold(root, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != len(scaffoldFiles) {
+		t.Errorf("got %d created files, want %d", len(created), len(scaffoldFiles))
+	}
+
+	b, err := os.ReadFile(filepath.Join(root, "days", "02", "day02.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"package day02", "aoc.Register(2, "} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("day02.go does not contain %q:\n%s", want, b)
+		}
+	}
+
+	b, err = os.ReadFile(days)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package days\n\nimport (\n\t_ \"github.com/pimvanhespen/aoc2022/days/01\"\n\t_ \"github.com/pimvanhespen/aoc2022/days/02\"\n\t_ \"github.com/pimvanhespen/aoc2022/days/03\"\n)\n"
+	if string(b) != want {
+		t.Errorf("got days.go\n%s\nwant\n%s", b, want)
+	}
+
+	// existing files are never overwritten
+	example := filepath.Join(root, "days", "02", "testdata", "example.txt")
+	if err = os.WriteFile(example, []byte("pasted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = scaffold(root, 2); err == nil {
+		t.Fatal("expected an error for an existing day")
+	}
+	if b, _ = os.ReadFile(example); string(b) != "pasted" {
+		t.Errorf("example was overwritten: %q", b)
+	}
+}
+
+func TestFetchExamples(t *testing.T) {
+	fixtures := t.TempDir()
+	b, err := os.ReadFile(filepath.Join("..", "..", "pkg", "aoc", "testdata", "puzzle.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(filepath.Join(fixtures, "2022", "01"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(fixtures, "2022", "01", "puzzle.html"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := aoctest.NewServer(fixtures, aoctest.Session)
+	t.Cleanup(srv.Close)
+
+	c := &aoc.Client{
+		BaseURL:     srv.URL,
+		HTTPClient:  srv.Client(),
+		Session:     aoctest.Session,
+		Dir:         t.TempDir(),
+		MinInterval: -1,
+	}
+
+	root := t.TempDir()
+	n, err := fetchExamples(c, root, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("got examples of %d parts, want 2", n)
+	}
+
+	got, err := aoc.ReadFixtures(filepath.Join(root, "days", "01", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Answer != "24000" || got[1].Part != 2 || got[1].Answer != "45000" {
+		t.Errorf("got fixtures %+v", got)
+	}
+}
+
+// blankTest is added to the scaffolded day, to check how its parse function handles blank lines.
+const blankTest = `package day25
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_blank(t *testing.T) {
+	input, err := parse(strings.NewReader("a\n\nb\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Input{"a", "", "b"}); !reflect.DeepEqual(input, want) {
+		t.Errorf("got %q, want %q", input, want)
+	}
+}
+`
+
+func TestScaffold_compiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go vet and go test")
+	}
+
+	repo, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// scaffold a day in a module of its own, that uses this module through a replace directive
+	root := t.TempDir()
+	mod := fmt.Sprintf("module scaffold\n\ngo 1.19\n\nrequire %s v0.0.0\n\nreplace %[1]s => %q\n", modulePath, repo)
+	if err = os.WriteFile(filepath.Join(root, "go.mod"), []byte(mod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(root, "days"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(root, "days", "days.go"), []byte("package days\n\nimport (\n)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = scaffold(root, 25); err != nil {
+		t.Fatal(err)
+	}
+
+	// days.go imports the day from this module, where it does not exist, so only the day is vetted
+	cmd := exec.Command("go", "vet", "./days/25")
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet: %v\n%s", err, out)
+	}
+
+	// the parse function of a new day relies on aoc.ParseRows turning a blank line into one empty row
+	if err = os.WriteFile(filepath.Join(root, "days", "25", "blank_test.go"), []byte(blankTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = exec.Command("go", "test", "-run", "TestParse_blank", "./days/25")
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go test: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+)
+
+// prefetch downloads the inputs of all unlocked days that are not cached yet.
+func prefetch(args []string) int {
+	fs := flag.NewFlagSet("prefetch", flag.ContinueOnError)
+	year := fs.Int("year", aoc.DefaultYear, "year of the event")
+	workers := fs.Int("workers", 4, "maximum number of concurrent downloads")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "unexpected arguments %q\n", fs.Args())
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results := aoc.DefaultClient.Prefetch(ctx, *year, *workers)
+
+	var failed bool
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Day\tStatus\tDuration\tError\t")
+	for _, r := range results {
+		var msg string
+		if r.Err != nil {
+			msg, failed = r.Err.Error(), true
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t\n", r.Puzzle.Day, r.Status, r.Duration.Round(time.Millisecond), msg)
+	}
+
+	if err := tw.Flush(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+	"os"
+	"strconv"
+)
+
+// runDays runs the selected parts of the selected days and prints a report.
+// It returns a non-zero exit code when a solver returned an error.
+func runDays(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "run only the given part (1 or 2), both parts are run when 0")
+	asJSON := fs.Bool("json", false, "print the report as JSON instead of a table")
+	record := fs.Bool("record", false, "record the answers in "+aoc.AnswersFile+" as the expected answers of the regression test")
+	render := fs.Bool("render", false, "render the days that support it to their output directory, after solving")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *part < 0 || *part > 2 {
+		_, _ = fmt.Fprintf(os.Stderr, "invalid part %d\n", *part)
+		return 2
+	}
+
+	days, err := selectDays(fs.Args())
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	var reports []aoc.Report
+	var failed bool
+
+	for _, day := range days {
+		s, _ := aoc.Lookup(day)
+
+		input, err := readInput(day)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "day %d: %v\n", day, err)
+			failed = true
+			continue
+		}
+
+		for _, p := range parts {
+			report := aoc.Run(day, s, p, input)
+			if report.Err != nil && !errors.Is(report.Err, aoc.ErrNotSolved) {
+				failed = true
+			}
+			reports = append(reports, report)
+		}
+
+		if *render {
+			err = aoc.Render(aoc.DefaultClient, s, input)
+			if err != nil && !errors.Is(err, aoc.ErrNoRenderer) {
+				_, _ = fmt.Fprintf(os.Stderr, "day %d: render: %v\n", day, err)
+				failed = true
+			}
+		}
+	}
+
+	write := aoc.WriteTable
+	if *asJSON {
+		write = aoc.WriteJSON
+	}
+
+	if err = write(os.Stdout, reports); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	printExcerpts(os.Stderr, reports)
+
+	if *record {
+		if err = recordAnswers(aoc.AnswersFile, reports); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// recordAnswers stores the answers of the successful reports in the answers file at path.
+func recordAnswers(path string, reports []aoc.Report) error {
+	answers, err := aoc.LoadAnswers(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		answers = make(aoc.Answers)
+	case err != nil:
+		return err
+	}
+
+	for _, r := range reports {
+		if r.Err == nil {
+			answers.Set(r.Day, r.Part, r.Answer)
+		}
+	}
+
+	return answers.Save(path)
+}
+
+// printExcerpts prints the offending input of the reports that failed on a parse error.
+// Both parts usually fail on the same error, it is printed once.
+func printExcerpts(w io.Writer, reports []aoc.Report) {
+	printed := make(map[string]bool)
+
+	for _, r := range reports {
+		var pe *aoc.ParseError
+		if !errors.As(r.Err, &pe) || printed[pe.Error()] {
+			continue
+		}
+		printed[pe.Error()] = true
+		_, _ = fmt.Fprintf(w, "%v\n%s", pe, pe.Excerpt())
+	}
+}
+
+// selectDays parses the days given as arguments, all registered days are returned when there are none.
+func selectDays(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return aoc.Days(), nil
+	}
+
+	var days []int
+	for _, arg := range args {
+		day, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q", arg)
+		}
+		if _, ok := aoc.Lookup(day); !ok {
+			return nil, fmt.Errorf("day %d is not solved", day)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func readInput(day int) ([]byte, error) {
+	r, err := aoc.Get(day)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
@@ -1,7 +1,6 @@
-package main
+package day01
 
 import (
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
 	"sort"
@@ -9,29 +8,8 @@ import (
 	"strings"
 )
 
-func main() {
-	input, err := aoc.Get(1)
-	if err != nil {
-		panic(err)
-	}
-
-	invs, err := parseInput(input)
-	if err != nil {
-		panic(err)
-	}
-
-	result1, err := solve1(invs)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 1:", result1)
-
-	result2, err := solve2(invs)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 2:", result2)
-
+func init() {
+	aoc.Register(1, aoc.NewSolution(parseInput, solve1, solve2))
 }
 
 // solve1 solves the question which Elf has the most calories.
@@ -71,36 +49,23 @@ func (i Elf) Calories() int {
 	return i.calories
 }
 
-func parseInput(reader io.Reader) (_ []Elf, err error) {
-	b, err := io.ReadAll(reader)
+func parseInput(reader io.Reader) ([]Elf, error) {
+	p := aoc.Parser[int]{
+		ParseFn: func(line string) (int, error) {
+			return strconv.Atoi(strings.TrimSpace(line))
+		},
+	}
+
+	blocks, err := p.Blocks(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(string(b), "\n")
-
-	var elves []Elf
-	var elf Elf
-
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-
-		if line == "" {
-			elves = append(elves, elf)
-			elf = Elf{}
-			continue
+	elves := make([]Elf, len(blocks))
+	for i, items := range blocks {
+		for _, item := range items {
+			elves[i].Add(item)
 		}
-
-		n, convErr := strconv.Atoi(line)
-		if convErr != nil {
-			return nil, fmt.Errorf("bad input on line %d: %w", i, convErr)
-		}
-
-		elf.Add(n)
-	}
-
-	if len(elf.items) > 0 {
-		elves = append(elves, elf)
 	}
 
 	return elves, nil
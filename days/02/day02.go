@@ -1,45 +1,36 @@
-package main
+package day02
 
 import (
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
 	"github.com/pimvanhespen/aoc2022/pkg/rps"
+	"io"
 )
 
 type inputRow struct {
 	left, right rune
 }
 
-func main() {
+func init() {
+	aoc.Register(2, aoc.NewSolution(parseRows, part1, part2))
+}
 
-	in, err := aoc.Get(2)
-	if err != nil {
-		panic(err)
-	}
+func part1(rows []inputRow) (int, error) {
+	return solve(rows, transformA, strategyA)
+}
+
+func part2(rows []inputRow) (int, error) {
+	return solve(rows, transformB, strategyB)
+}
 
+func parseRows(r io.Reader) ([]inputRow, error) {
 	parser := aoc.Parser[inputRow]{
 		SkipEmptyLines: true,
 		ParseFn:        parseLine,
 	}
 
-	invs, err := parser.Rows(in)
-	if err != nil {
-		panic(err)
-	}
-
-	result1, err := solve(invs, transformA, strategyA)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 1:", result1)
-
-	result2, err := solve(invs, transformB, strategyB)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 2:", result2)
-
+	return parser.Rows(r)
 }
 
 func parseLine(line string) (inputRow, error) {
@@ -79,30 +70,43 @@ func parseHand(r rune) (rps.Hand, error) {
 	}
 }
 
-func outcomeValue(outcome rps.Outcome) int {
+func outcomeValue(outcome rps.Outcome) (int, error) {
 	switch outcome {
 	case rps.Loss:
-		return 0
+		return 0, nil
 	case rps.Draw:
-		return 3
+		return 3, nil
 	case rps.Win:
-		return 6
+		return 6, nil
 	default:
-		panic("unknown outcome")
+		return 0, fmt.Errorf("unknown outcome %v", outcome)
 	}
 }
 
-func handValue(hand rps.Hand) int {
+func handValue(hand rps.Hand) (int, error) {
 	switch hand {
 	case rps.Rock:
-		return 1
+		return 1, nil
 	case rps.Paper:
-		return 2
+		return 2, nil
 	case rps.Scissors:
-		return 3
+		return 3, nil
 	default:
-		panic("unknown hand")
+		return 0, fmt.Errorf("unknown hand %v", hand)
+	}
+}
+
+// score returns the score of a round with the given outcome and hand of the player.
+func score(outcome rps.Outcome, hand rps.Hand) (int, error) {
+	o, err := outcomeValue(outcome)
+	if err != nil {
+		return 0, err
+	}
+	h, err := handValue(hand)
+	if err != nil {
+		return 0, err
 	}
+	return o + h, nil
 }
 
 func solve[T any](
@@ -158,8 +162,7 @@ func strategyA(i inputA) (int, error) {
 		return 0, err
 	}
 
-	res := outcomeValue(outcome) + handValue(i.PlayerHand)
-	return res, nil
+	return score(outcome, i.PlayerHand)
 }
 
 type inputB struct {
@@ -191,6 +194,5 @@ func strategyB(i inputB) (int, error) {
 		return 0, err
 	}
 
-	res := outcomeValue(i.DesiredOutcome) + handValue(handToPlay)
-	return res, nil
+	return score(i.DesiredOutcome, handToPlay)
 }
@@ -1,37 +1,16 @@
-package main
+package day03
 
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/set"
 	"io"
 )
 
-func main() {
-	in, err := aoc.Get(3)
-	if err != nil {
-		panic(err)
-	}
-
-	rows, err := parseInput(in)
-	if err != nil {
-		panic(err)
-	}
-
-	result, err := solve1(rows)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 1:", result)
-
-	result, err = solve2(rows)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 2:", result)
+func init() {
+	aoc.Register(3, aoc.NewSolution(parseInput, solve1, solve2))
 }
 
 func solve1(rucksacks [][]byte) (int, error) {
@@ -58,7 +37,7 @@ func solve1(rucksacks [][]byte) (int, error) {
 
 func solve2(rs [][]byte) (int, error) {
 	if len(rs)%3 != 0 {
-		panic("not a multiple of 3")
+		return 0, errors.New("number of rucksacks is not a multiple of 3")
 	}
 
 	var total int
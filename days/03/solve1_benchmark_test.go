@@ -1,9 +1,10 @@
-package main
+package day03
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aocbench"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
-	"os"
 	"strings"
 	"testing"
 )
@@ -26,15 +27,7 @@ import (
 // BenchmarkSolve1/bytes-8       	   60631	     19754 ns/op	       0 B/op	       0 allocs/op
 func BenchmarkSolve1(b *testing.B) {
 
-	f, err := os.Open("input.txt")
-	if err != nil {
-		b.Fatal(err)
-	}
-	bs, err := parseInput(f)
-	if err != nil {
-		b.Fatal(err)
-	}
-	err = f.Close()
+	bs, err := parseInput(bytes.NewReader(aocbench.CachedInput(b, "../..", 3)))
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -1,10 +1,9 @@
-package main
+package day03
 
 import (
 	"bytes"
 	"fmt"
-	"io"
-	"os"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aocbench"
 	"testing"
 )
 
@@ -26,20 +25,7 @@ import (
 // BenchmarkSolve2/solve2_bytes_ref-8      81895	     14618 ns/op	       0 B/op	       0 allocs/op
 func BenchmarkSolve2(b *testing.B) {
 
-	f, err := os.Open("input.txt")
-	if err != nil {
-		b.Fatal(err)
-	}
-	defer func() {
-		if e := f.Close(); e != nil {
-			b.Fatal(e)
-		}
-	}()
-
-	bts, err := io.ReadAll(f)
-	if err != nil {
-		b.Fatal(err)
-	}
+	bts := aocbench.CachedInput(b, "../..", 3)
 
 	bs, err := parseInput(bytes.NewReader(bts))
 	if err != nil {
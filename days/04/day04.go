@@ -0,0 +1,50 @@
+package day04
+
+import (
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
+	"io"
+)
+
+type Pair struct {
+	Left, Right Range
+}
+
+type Range struct {
+	Min, Max int
+}
+
+func (r Range) Contains(other Range) bool {
+	return r.Min <= other.Min && r.Max >= other.Max
+}
+
+func (r Range) Overlaps(other Range) bool {
+	return r.Min <= other.Max && r.Max >= other.Min
+}
+
+var pairPattern = aoc.MustPattern[Pair]("{Left.Min}-{Left.Max},{Right.Min}-{Right.Max}")
+
+func init() {
+	aoc.Register(4, aoc.NewSolution(parseRows, aoc.Infallible(countEngulfing), aoc.Infallible(countOverlapping)))
+}
+
+func countEngulfing(rows []Pair) int {
+	return list.Count(rows, func(p Pair) bool {
+		return p.Left.Contains(p.Right) || p.Right.Contains(p.Left)
+	})
+}
+
+func countOverlapping(rows []Pair) int {
+	return list.Count(rows, func(p Pair) bool {
+		return p.Left.Overlaps(p.Right)
+	})
+}
+
+func parseRows(r io.Reader) ([]Pair, error) {
+	p := aoc.Parser[Pair]{
+		SkipEmptyLines: false,
+		ParseFn:        pairPattern.Parse,
+	}
+
+	return p.Rows(r)
+}
@@ -1,10 +1,10 @@
-package main
+package day05
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/stack"
+	"io"
 	"strings"
 )
 
@@ -71,31 +71,33 @@ func (i Instruction) String() string {
 	return fmt.Sprintf("move %d from %d to %d", i.Amount, i.From+1, i.To+1)
 }
 
-func main() {
+func init() {
+	aoc.Register(5, aoc.NewSolution(parse, aoc.Infallible(part1), aoc.Infallible(part2)))
+}
 
-	rc, err := aoc.Get(5)
-	if err != nil {
-		panic(err)
-	}
-	defer rc.Close()
+func part1(in Input) string {
+	return solve1(in.Harbor, in.Instructions)
+}
 
-	scanner := bufio.NewScanner(rc)
+func part2(in Input) string {
+	return solve2(in.Harbor, in.Instructions)
+}
 
-	h1, err := ParseHarbor(scanner)
-	if err != nil {
-		panic(err)
-	}
+// Input is the harbor with the instructions for the crane.
+type Input struct {
+	Harbor       Harbor
+	Instructions []Instruction
+}
 
-	instructions, err := ParseInstructions(scanner)
-	if err != nil {
-		panic(err)
-	}
+func parse(r io.Reader) (Input, error) {
+	var in Input
 
-	part1 := solve1(h1.Copy(), instructions)
-	fmt.Println("Part 1:", part1)
+	err := aoc.ParseSections(r,
+		aoc.Section(&in.Harbor, ParseHarbor),
+		aoc.Section(&in.Instructions, ParseInstructions),
+	)
 
-	part2 := solve2(h1.Copy(), instructions)
-	fmt.Println("Part 2:", part2)
+	return in, err
 }
 
 func solve1(harbor Harbor, instructions []Instruction) string {
@@ -156,16 +158,15 @@ func parseStackRow(line string) []byte {
 	return stacks
 }
 
-func ParseHarbor(scanner *bufio.Scanner) (Harbor, error) {
+// ParseHarbor parses the drawing of the stacks, the line with the stack numbers at the bottom is ignored.
+func ParseHarbor(block aoc.Block) (Harbor, error) {
 
 	var inputs [][]byte
 	var max int
 
 	// Read each horizontal line of stack values
-	for scanner.Scan() {
-		line := scanner.Text()
+	for _, line := range block.Lines {
 		if strings.IndexByte(line, '[') == -1 {
-			scanner.Scan()
 			break
 		}
 
@@ -195,19 +196,14 @@ func ParseHarbor(scanner *bufio.Scanner) (Harbor, error) {
 	return Harbor{stacks: stacks}, nil
 }
 
-func ParseInstructions(scanner *bufio.Scanner) ([]Instruction, error) {
+func ParseInstructions(block aoc.Block) ([]Instruction, error) {
 	var instructions []Instruction
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			break
-		}
-
+	for i, line := range block.Lines {
 		var ins Instruction
 		_, err := fmt.Sscanf(line, "move %d from %d to %d", &ins.Amount, &ins.From, &ins.To)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse instruction: %w", err)
+			return nil, block.ErrorAt(i, 0, fmt.Errorf("could not parse instruction: %w", err))
 		}
 
 		// input isn't zero indexed, the harbor is
@@ -0,0 +1,71 @@
+package day06
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+)
+
+func init() {
+	aoc.Register(6, aoc.NewSolution(io.ReadAll, solve1, solve2))
+}
+
+func solve1(bts []byte) (int, error) {
+	return findMarker(bts, 4)
+}
+
+func solve2(bts []byte) (int, error) {
+	return findMarker(bts, 14)
+}
+
+// findMarker returns the number of characters up to and including the first size characters that are all different.
+func findMarker(bts []byte, size int) (int, error) {
+	for i := 0; i+size <= len(bts); i++ {
+		if allDifferent(bts[i : i+size]) {
+			return i + size, nil
+		}
+	}
+	return 0, fmt.Errorf("no marker of %d different characters", size)
+}
+
+func allDifferent(bts []byte) bool {
+	for i := 0; i < len(bts)-1; i++ {
+		if bytes.IndexByte(bts[i+1:], bts[i]) != -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// -- alternatives --
+
+func solve_map(bts []byte, size int) int {
+	var m = make(map[byte]int)
+	var c byte
+	for i := 0; i < len(bts); i++ {
+		if i > size-1 {
+
+			// the character leaving the window was counted when it entered it
+			c = bts[i-size]
+
+			if m[c] == 1 {
+				delete(m, c)
+			} else {
+				m[c]--
+			}
+		}
+
+		c = bts[i]
+		if n, ok := m[c]; ok {
+			m[c] = n + 1
+		} else {
+			m[c] = 1
+		}
+
+		if len(m) == size {
+			return i + 1
+		}
+	}
+	return -1
+}
@@ -1,9 +1,8 @@
-package main
+package day06
 
 import (
 	"fmt"
-	"io"
-	"os"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aocbench"
 	"testing"
 )
 
@@ -47,7 +46,11 @@ func Test_solve1(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := solve1(tt.args.bts); got != tt.want {
+			got, err := solve1(tt.args.bts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
 				t.Errorf("solve1() = %v, want %v", got, tt.want)
 			}
 		})
@@ -188,32 +191,37 @@ func Test_solve2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := solve2(tt.args.bts); got != tt.want {
+			got, err := solve2(tt.args.bts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
 				t.Errorf("solve2() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func BenchmarkSolve1(b *testing.B) {
-
-	f, err := os.Open("input.txt")
-	if err != nil {
-		b.Fatal(err)
+func Test_solve_noMarker(t *testing.T) {
+	if _, err := solve1([]byte("abcabc")); err == nil {
+		t.Error("expected an error when there is no marker")
 	}
-	defer f.Close()
-
-	input, err := io.ReadAll(f)
-	if err != nil {
-		b.Error(err)
+	if _, err := solve2([]byte("ab")); err == nil {
+		t.Error("expected an error for a short input")
 	}
+}
+
+func BenchmarkSolve1(b *testing.B) {
+
+	input := aocbench.CachedInput(b, "../..", 6)
 
 	var total int
 
 	b.Run("loop->bytes.Index", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			total += solve1(input)
+			n, _ := solve1(input)
+			total += n
 		}
 	})
 
@@ -229,23 +237,15 @@ func BenchmarkSolve1(b *testing.B) {
 
 func BenchmarkSolve2(b *testing.B) {
 
-	f, err := os.Open("input.txt")
-	if err != nil {
-		b.Fatal(err)
-	}
-	defer f.Close()
-
-	input, err := io.ReadAll(f)
-	if err != nil {
-		b.Error(err)
-	}
+	input := aocbench.CachedInput(b, "../..", 6)
 
 	var total int
 
 	b.Run("loop->bytes.Index", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			total += solve2(input)
+			n, _ := solve2(input)
+			total += n
 		}
 	})
 
@@ -1,4 +1,4 @@
-package main
+package day07
 
 import (
 	"bufio"
@@ -73,19 +73,8 @@ func (f *File) Size() int {
 	return f.size
 }
 
-func main() {
-	rc, err := aoc.Get(7)
-	if err != nil {
-		panic(err)
-	}
-
-	root, err := parse(rc)
-	if err != nil {
-		panic(err)
-	}
-
-	fmt.Println("Part 1:", solve1(root))
-	fmt.Println("Part 2:", solve2(root))
+func init() {
+	aoc.Register(7, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
 }
 
 func parse(reader io.Reader) (*Directory, error) {
@@ -1,35 +1,13 @@
-package main
+package day08
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
 )
 
-func main() {
-	r, err := aoc.Get(8)
-	if err != nil {
-		panic(err)
-	}
-
-	field, err := parse(r)
-	if err != nil {
-		panic(err)
-	}
-
-	// Part 1
-	r1, err := solve1(field)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 1:", r1)
-
-	r2, err := solve2(field)
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Part 2:", r2)
+func init() {
+	aoc.Register(8, aoc.NewSolution(parse, solve1, solve2))
 }
 
 func parse(rc io.Reader) ([][]byte, error) {
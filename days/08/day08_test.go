@@ -1,4 +1,4 @@
-package main
+package day08
 
 import (
 	"bytes"
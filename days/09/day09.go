@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"bufio"
@@ -7,25 +7,12 @@ import (
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"image"
 	"image/color"
-	"image/png"
 	"io"
 	"math"
 )
 
-func main() {
-	reader, err := aoc.Get(9)
-	if err != nil {
-		panic(err)
-	}
-
-	input, err := parse(reader)
-	if err != nil {
-		panic(err)
-	}
-
-	fmt.Println("Part 1:", solve1(input))
-	fmt.Println("Part 2:", solve2(input))
-
+func init() {
+	aoc.Register(9, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)).WithRender(render))
 }
 
 var (
@@ -145,6 +132,13 @@ func solve2(input []Move) int {
 }
 
 func solve(input []Move, knots int) int {
+	visited, _ := simulate(input, knots)
+	return len(visited[len(visited)-1])
+}
+
+// simulate moves a rope of the given number of knots, it returns the positions visited by every knot
+// and the final rope.
+func simulate(input []Move, knots int) ([]map[Vector]struct{}, []Vector) {
 
 	//tailMoves := make(map[Vector]struct{}) // tail moves <- used in original solution
 
@@ -177,20 +171,7 @@ func solve(input []Move, knots int) int {
 		}
 	}
 
-	// store outputs
-	floor := renderFloor(visited[len(visited)-1], rope)
-	aoc.SaveOutput(9, fmt.Sprintf("floor_%d.txt", knots), func(writer io.Writer) error {
-		_, err := writer.Write(floor)
-		return err
-	})
-
-	img := createImage(visited)
-	filename := fmt.Sprintf("image_%d.png", knots)
-	aoc.SaveOutput(9, filename, func(writer io.Writer) error {
-		return png.Encode(writer, img)
-	})
-
-	return len(visited[len(visited)-1])
+	return visited, rope
 }
 
 func limit(n int) int {
@@ -212,6 +193,25 @@ func abs(x int) int {
 
 //														//
 // -- rendering -- NOT A PART OF THE ACTUAL SOLUTION -- //
+
+// render saves the floor visited by the tail and an image of the visits of all knots, for both parts.
+func render(c *aoc.Client, input []Move) error {
+	for _, knots := range []int{2, 10} {
+		visited, rope := simulate(input, knots)
+
+		floor := renderFloor(visited[len(visited)-1], rope)
+		if err := c.SaveOutput(9, fmt.Sprintf("floor_%d.txt", knots), floor); err != nil {
+			return err
+		}
+
+		img := createImage(visited)
+		if err := c.SaveOutput(9, fmt.Sprintf("image_%d.png", knots), img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 //														//
 
 // renderFloor prints the floor with all the tiles visited marked with '#'.
@@ -1,6 +1,7 @@
-package main
+package day09
 
 import (
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"strings"
 	"testing"
 )
@@ -46,3 +47,26 @@ U 20`)
 		t.Errorf("got %d, want %d", got, want)
 	}
 }
+
+func TestRender(t *testing.T) {
+	in, err := parse(strings.NewReader(`R 4
+U 4
+L 3
+D 1`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &aoc.Client{Dir: t.TempDir()}
+	if err = render(c, in); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := c.Outputs(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 4 {
+		t.Errorf("got %d outputs, want the floors and images of both parts: %+v", len(outputs), outputs)
+	}
+}
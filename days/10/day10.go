@@ -1,4 +1,4 @@
-package main
+package day10
 
 import (
 	"bufio"
@@ -13,21 +13,8 @@ type Instruction struct {
 	Arg int
 }
 
-func main() {
-	r, err := aoc.Get(10)
-	if err != nil {
-		panic(err)
-	}
-
-	in, err := parse(r)
-	if err != nil {
-		panic(err)
-	}
-
-	// Part 1
-	s1 := solve1(in)
-	fmt.Println("Part 1:", s1)
-	//fmt.Println("Part 2:", solve2(in))
+func init() {
+	aoc.Register(10, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
 }
 
 func parse(reader io.Reader) ([]Instruction, error) {
@@ -56,6 +43,18 @@ func parse(reader io.Reader) ([]Instruction, error) {
 }
 
 func solve1(ins []Instruction) int {
+	sum, _ := execute(ins)
+	return sum
+}
+
+// solve2 returns the image that is drawn on the CRT, lit pixels are drawn as '#'.
+func solve2(ins []Instruction) string {
+	_, screen := execute(ins)
+	return screen
+}
+
+// execute runs the instructions and returns the sum of the signal strengths and the drawn image.
+func execute(ins []Instruction) (int, string) {
 	var sum int
 	var X int
 	var cycle int
@@ -89,13 +88,7 @@ func solve1(ins []Instruction) int {
 		}
 	}
 
-	fmt.Println(sb.String())
-
-	return sum
-}
-
-func solve2(in []byte) int {
-	return 0
+	return sum, sb.String()
 }
 
 func abs(x int) int {
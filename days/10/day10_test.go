@@ -1,4 +1,4 @@
-package main
+package day10
 
 import (
 	"strings"
@@ -165,3 +165,23 @@ func TestSolve1(t *testing.T) {
 		t.Fatalf("got %d, want %d", got, want)
 	}
 }
+
+func TestSolve2(t *testing.T) {
+	want := strings.ReplaceAll(`##..##..##..##..##..##..##..##..##..##..
+###...###...###...###...###...###...###.
+####....####....####....####....####....
+#####.....#####.....#####.....#####.....
+######......######......######......####
+#######.......#######.......#######.....
+`, ".", " ")
+
+	in, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := solve2(in)
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+package day11
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+	"sort"
+	"strconv"
+)
+
+func init() {
+	aoc.Register(11, aoc.NewSolution(parse, aoc.Infallible(part1), aoc.Infallible(part2)))
+}
+
+func part1(monkeys []*Monkey) int {
+	return solve1(monkeys, 20)
+}
+
+// part2 keeps the worry levels manageable by reducing them modulo the product of all divisors,
+// which does not change the outcome of any of the divisibility checks.
+func part2(monkeys []*Monkey) int {
+	combined := 1
+	for _, m := range monkeys {
+		combined *= m.Divisor
+	}
+
+	return simulate(monkeys, 10_000, func(i int) int {
+		return i % combined
+	})
+}
+
+type Monkey struct {
+	Inspections int
+	Op          func(int) int
+	Check       func(int) int
+	Divisor     int
+	Initial     []int
+	Items       []int
+}
+
+type Report struct {
+	Item   int
+	Target int
+}
+
+func (m *Monkey) Inspect(adjustWorry func(int) int) (Report, bool) {
+	if len(m.Items) == 0 {
+		return Report{}, false
+	}
+	m.Inspections++
+
+	item := m.Items[0]
+	m.Items = m.Items[1:]
+
+	item = m.Op(item)
+	item = adjustWorry(item)
+
+	return Report{item, m.Check(item)}, true
+}
+
+func (m *Monkey) Reset() {
+	m.Items = make([]int, len(m.Initial))
+	copy(m.Items, m.Initial)
+	m.Inspections = 0
+}
+
+func (m *Monkey) Receive(item int) {
+	m.Items = append(m.Items, item)
+}
+
+func (m Monkey) String() string {
+	return fmt.Sprintf("Monkey:  inspections=%d, items=%v", m.Inspections, m.Items)
+}
+
+func solve1(monkeys []*Monkey, rounds int) int {
+	worryFn := func(i int) int { return i / 3 }
+	return simulate(monkeys, rounds, worryFn)
+}
+
+func simulate(monkeys []*Monkey, rounds int, worryFn func(int) int) int {
+
+	for i := 0; i < rounds; i++ {
+		for _, m := range monkeys {
+			for {
+				report, ok := m.Inspect(worryFn)
+				if !ok {
+					break
+				}
+				monkeys[report.Target].Receive(report.Item)
+			}
+		}
+	}
+
+	ints := make([]int, len(monkeys))
+	for i, m := range monkeys {
+		ints[i] = m.Inspections
+	}
+
+	sort.Ints(ints)
+
+	return ints[len(ints)-1] * ints[len(ints)-2]
+}
+
+type MathFn func(int, int) int
+
+func add(a, b int) int {
+	return a + b
+}
+
+func sub(a, b int) int {
+	return a - b
+}
+
+func mul(a, b int) int {
+	return a * b
+}
+
+func div(a, b int) int {
+	return a / b
+}
+
+func parse(reader io.Reader) ([]*Monkey, error) {
+	return aoc.ParseBlocks(reader, parseMonkey)
+}
+
+var (
+	itemsPattern     = aoc.MustPattern[struct{ Items []int }]("  Starting items: {Items:, }")
+	operationPattern = aoc.MustPattern[struct{ Op, Arg string }]("  Operation: new = old {Op} {Arg}")
+	testPattern      = aoc.MustPattern[struct{ Divisor int }]("  Test: divisible by {Divisor}")
+	truePattern      = aoc.MustPattern[struct{ Monkey int }]("    If true: throw to monkey {Monkey}")
+	falsePattern     = aoc.MustPattern[struct{ Monkey int }]("    If false: throw to monkey {Monkey}")
+)
+
+func parseMonkey(block aoc.Block) (*Monkey, error) {
+	if len(block.Lines) != 6 {
+		return nil, fmt.Errorf("monkey has %d lines, want 6", len(block.Lines))
+	}
+
+	var monkey Monkey
+
+	// first line: items
+	items, err := itemsPattern.Parse(block.Lines[1])
+	if err != nil {
+		return nil, block.AtLine(1, err)
+	}
+	monkey.Initial = items.Items
+
+	// second line: Operation
+	operation, err := operationPattern.Parse(block.Lines[2])
+	if err != nil {
+		return nil, block.AtLine(2, err)
+	}
+
+	var m MathFn
+
+	switch operation.Op {
+	case "+":
+		m = add
+	case "-":
+		m = sub
+	case "*":
+		m = mul
+	case "/":
+		m = div
+	default:
+		return nil, block.ErrorAt(2, 0, fmt.Errorf("unknown operator %q", operation.Op))
+	}
+
+	n, err := strconv.Atoi(operation.Arg)
+	switch {
+	case operation.Arg == "old":
+		monkey.Op = func(old int) int {
+			return m(old, old)
+		}
+	case err != nil:
+		return nil, block.ErrorAt(2, 0, fmt.Errorf("invalid argument %q", operation.Arg))
+	default:
+		monkey.Op = func(old int) int {
+			return m(old, n)
+		}
+	}
+
+	// third line: Check
+	test, err := testPattern.Parse(block.Lines[3])
+	if err != nil {
+		return nil, block.AtLine(3, err)
+	}
+	divisor := test.Divisor
+	if divisor == 0 {
+		return nil, block.ErrorAt(3, 0, errors.New("divisor is 0"))
+	}
+
+	monkey.Divisor = divisor
+
+	whenTrue, err := truePattern.Parse(block.Lines[4])
+	if err != nil {
+		return nil, block.AtLine(4, err)
+	}
+	whenFalse, err := falsePattern.Parse(block.Lines[5])
+	if err != nil {
+		return nil, block.AtLine(5, err)
+	}
+
+	monkey.Check = func(n int) int {
+		if n%divisor == 0 {
+			return whenTrue.Monkey
+		}
+		return whenFalse.Monkey
+	}
+
+	monkey.Reset()
+	return &monkey, nil
+}
@@ -1,4 +1,4 @@
-package main
+package day11
 
 import (
 	"strings"
@@ -40,9 +40,7 @@ func TestSolve2(t *testing.T) {
 	}
 
 	const want = 2713310158
-	got := simulate(m, 10_000, func(i int) int {
-		return i / 2
-	})
+	got := part2(m)
 	if got != want {
 		t.Errorf("got %d, want %d", got, want)
 	}
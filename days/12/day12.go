@@ -1,7 +1,8 @@
-package main
+package day12
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/queue"
@@ -9,27 +10,32 @@ import (
 	"math"
 )
 
-func main() {
-	r, err := aoc.Get(12)
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	aoc.Register(12, aoc.NewSolution(parseInput, part1, part2))
+}
+
+// Input is the height map with the start and end positions.
+type Input struct {
+	Chart      Chart
+	Start, End Tile
+}
 
+func parseInput(r io.Reader) (Input, error) {
 	bts, err := io.ReadAll(r)
 	if err != nil {
-		panic(err)
+		return Input{}, err
 	}
 
-	fmt.Println(bytes.Count(bts, []byte("a")))
-
 	c, b, e := parse(bts)
+	return Input{Chart: c, Start: b, End: e}, nil
+}
 
-	// Part 1
-	p1 := solve1(c, b, e)
-	fmt.Println("Part 1:", p1)
+func part1(in Input) (int, error) {
+	return solve1(in.Chart, in.Start, in.End)
+}
 
-	p2 := solve2(c, e)
-	fmt.Println("Part 2:", p2)
+func part2(in Input) (int, error) {
+	return solve2(in.Chart, in.End)
 }
 
 func parse(data []byte) (Chart, Tile, Tile) {
@@ -67,7 +73,7 @@ func parse(data []byte) (Chart, Tile, Tile) {
 
 // -- Solution --
 
-func solve1(chart Chart, start, end Tile) int {
+func solve1(chart Chart, start, end Tile) (int, error) {
 
 	heuristic := func(t Tile) int {
 		return ManhattanDistance(t, end)
@@ -87,13 +93,12 @@ func solve1(chart Chart, start, end Tile) int {
 
 	path := AStar(start, end, heuristic, cost, neighbours)
 	if path == nil {
-		panic("no path found")
+		return 0, errors.New("no path found")
 	}
-	printRoute(path)
-	return len(path[1:])
+	return len(path[1:]), nil
 }
 
-func solve2(chart Chart, end Tile) int {
+func solve2(chart Chart, end Tile) (int, error) {
 	heuristic := func(t Tile) int {
 		return ManhattanDistance(t, end)
 	}
@@ -113,11 +118,10 @@ func solve2(chart Chart, end Tile) int {
 	// Find clusters of 'a' so that we can skip unreachable clusters later on
 	clusters := chart.Clusters('a')
 	if len(clusters) == 0 {
-		panic("no options")
+		return 0, errors.New("no tiles at elevation 'a'")
 	}
 
 	minimum := math.MaxInt
-	var shortest []Tile // for printing the output
 
 outer:
 	for _, cluster := range clusters {
@@ -131,14 +135,15 @@ outer:
 			steps := len(route) - 1
 
 			if steps < minimum {
-				shortest = route
 				minimum = steps
 			}
 		}
 	}
 
-	printRoute(shortest)
-	return minimum
+	if minimum == math.MaxInt {
+		return 0, errors.New("no path found")
+	}
+	return minimum, nil
 }
 
 type Tile struct {
@@ -146,10 +151,6 @@ type Tile struct {
 	Elevation byte
 }
 
-func (t Tile) Equals(other Tile) bool {
-	return t.X == other.X && t.Y == other.Y
-}
-
 type Chart [][]Tile
 
 func (m Chart) Get(x, y int) (Tile, bool) {
@@ -193,24 +194,33 @@ func (m Chart) Clusters(c byte) [][]Tile {
 				continue
 			}
 
-			clusters = append(clusters, getClusterRecursive(m, cache, tile))
+			clusters = append(clusters, getCluster(m, cache, tile))
 		}
 	}
 
 	return clusters
 }
 
-func getClusterRecursive(m Chart, cache map[Tile]struct{}, t Tile) []Tile {
-	if _, ok := cache[t]; ok {
-		return nil
-	}
-
+// getCluster returns the tiles that are connected to t at the same elevation, with a breadth-first fill.
+func getCluster(m Chart, cache map[Tile]struct{}, t Tile) []Tile {
 	cluster := []Tile{t}
 	cache[t] = struct{}{}
 
-	for _, n := range m.GetDirectNeighbours(t.X, t.Y) {
-		if n.Elevation == t.Elevation {
-			cluster = append(cluster, getClusterRecursive(m, cache, n)...)
+	todo := queue.NewDeque(t)
+	for todo.Len() > 0 {
+		current := todo.PopFront()
+
+		for _, n := range m.GetDirectNeighbours(current.X, current.Y) {
+			if n.Elevation != current.Elevation {
+				continue
+			}
+			if _, ok := cache[n]; ok {
+				continue
+			}
+
+			cache[n] = struct{}{}
+			cluster = append(cluster, n)
+			todo.PushBack(n)
 		}
 	}
 
@@ -236,7 +246,7 @@ type NeighboursFunc func(Tile) []Tile
 
 func AStar(start, end Tile, h HeuristicFunc, costFn CostFunc, neighbours NeighboursFunc) []Tile {
 
-	pq := queue.NewPriority[Tile]()
+	pq := queue.NewHeap[Tile, int](queue.Min)
 	pq.Insert(start, 0)
 
 	cameFrom := make(map[Tile]Tile)
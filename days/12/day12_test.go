@@ -0,0 +1,43 @@
+package day12
+
+import (
+	"testing"
+)
+
+var input = []byte(`Sabqponm
+abcryxxl
+accszExk
+acctuvwj
+abdefghi`)
+
+func Test_solve1(t *testing.T) {
+
+	const want = 31
+
+	chart, b, e := parse(input)
+
+	got, err := solve1(chart, b, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("solve1() = %v, want %v", got, want)
+	}
+}
+
+func Test_solve2(t *testing.T) {
+
+	const want = 29
+
+	chart, _, e := parse(input)
+
+	got, err := solve2(chart, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != want {
+		t.Errorf("solve2() = %v, want %v", got, want)
+	}
+}
@@ -1,7 +1,6 @@
-package main
+package day13
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
@@ -117,21 +116,8 @@ func (l ListValue) Compare(other Value) int {
 
 type Packet = ListValue
 
-func main() {
-	r, err := aoc.Get(13)
-	if err != nil {
-		panic(err)
-	}
-
-	packets := parse(r)
-
-	// Part 1
-	sum1 := solve1(packets)
-	println("Part1:", sum1)
-
-	// Part 2
-	sum2 := solve2(packets)
-	println("Part2:", sum2)
+func init() {
+	aoc.Register(13, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
 }
 
 func solve1(packets [][2]Packet) int {
@@ -147,8 +133,8 @@ func solve1(packets [][2]Packet) int {
 }
 
 func solve2(pairs [][2]Packet) int {
-	_, divA := parsePacket("[[2]]")
-	_, divB := parsePacket("[[6]]")
+	divA := Packet{Packet{IntValue(2)}}
+	divB := Packet{Packet{IntValue(6)}}
 
 	packets := make([]Packet, 0, (len(pairs)+1)*2)
 	packets = append(packets, divA, divB)
@@ -175,55 +161,65 @@ func solve2(pairs [][2]Packet) int {
 	return total
 }
 
-func parse(r io.Reader) [][2]Packet {
-	scanner := bufio.NewScanner(r)
-
-	var packets [][2]Packet
+func parse(r io.Reader) ([][2]Packet, error) {
+	return aoc.ParseBlocks(r, parsePair)
+}
 
-	for scanner.Scan() {
-		var pair [2]Packet
+func parsePair(block aoc.Block) ([2]Packet, error) {
+	var pair [2]Packet
 
-		for i := range pair {
-			s := scanner.Text()
-			scanner.Scan() // cycle for next round
+	if len(block.Lines) != len(pair) {
+		return pair, fmt.Errorf("pair has %d packets, want %d", len(block.Lines), len(pair))
+	}
 
-			n, p := parsePacket(s)
-			if n != len(s) {
-				panic(fmt.Sprintf("expected %d, got %d.\n'%s'\n%s", len(s), n, s, p.String()))
-			}
-			pair[i] = p
+	for i, s := range block.Lines {
+		n, p, err := parsePacket(s)
+		if err != nil {
+			return pair, block.ErrorAt(i, n+1, err)
 		}
-
-		packets = append(packets, pair)
+		if n != len(s) {
+			return pair, block.ErrorAt(i, n+1, fmt.Errorf("invalid packet: parsed %d of %d characters", n, len(s)))
+		}
+		pair[i] = p
 	}
 
-	return packets
+	return pair, nil
 }
 
-func parsePacket(s string) (int, Packet) {
-	n, p := parseListItem(s[1:])
-	return n + 1, p
+// parsePacket returns the number of characters parsed and the packet at the start of s.
+func parsePacket(s string) (int, Packet, error) {
+	if !strings.HasPrefix(s, "[") {
+		return 0, nil, fmt.Errorf("packet does not start with '['")
+	}
+	n, p, err := parseListItem(s[1:])
+	return n + 1, p, err
 }
 
-func parseListItem(s string) (int, ListValue) {
+func parseListItem(s string) (int, ListValue, error) {
 	var l ListValue
 
 	for i := 0; i < len(s); i++ {
 		switch s[i] {
 		case '[':
-			n, sub := parseListItem(s[i+1:])
+			n, sub, err := parseListItem(s[i+1:])
+			if err != nil {
+				return i + 1 + n, nil, err
+			}
 			l = append(l, sub)
 			i += n
 		case ']':
-			return i + 1, l
+			return i + 1, l, nil
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 
 			end := strings.IndexAny(s[i:], ",]")
 			if end == -1 {
-				panic(fmt.Sprintf("no end found for %s", s[i:]))
+				return i, nil, fmt.Errorf("no end found for %s", s[i:])
 			}
 
-			n, _ := strconv.Atoi(s[i : i+end])
+			n, err := strconv.Atoi(s[i : i+end])
+			if err != nil {
+				return i, nil, err
+			}
 			l = append(l, IntValue(n))
 			i += end - 1
 		case ',':
@@ -232,5 +228,5 @@ func parseListItem(s string) (int, ListValue) {
 		}
 	}
 
-	return len(s), l
+	return len(s), nil, fmt.Errorf("no end found for list")
 }
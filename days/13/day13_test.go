@@ -1,4 +1,4 @@
-package main
+package day13
 
 import (
 	"strings"
@@ -30,7 +30,10 @@ const testInput = `[1,1,3,1,1]
 [1,[2,[3,[4,[5,6,0]]]],8,9]`
 
 func TestSolve1(t *testing.T) {
-	packets := parse(strings.NewReader(testInput))
+	packets, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 	num := solve1(packets)
 
 	if num != 13 {
@@ -39,7 +42,10 @@ func TestSolve1(t *testing.T) {
 }
 
 func TestSolve2(t *testing.T) {
-	packets := parse(strings.NewReader(testInput))
+	packets, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 	num := solve2(packets)
 
 	if num != 140 {
@@ -83,7 +89,10 @@ func Test_parsePacket(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, got := parsePacket(tt.arg)
+			_, got, err := parsePacket(tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
 			if got.String() != tt.arg {
 				t.Errorf("parseListItem() got = %v, want %v", got, tt.arg)
 			}
@@ -91,6 +100,18 @@ func Test_parsePacket(t *testing.T) {
 	}
 }
 
+func Test_parsePacket_invalid(t *testing.T) {
+	for _, s := range []string{"", "1,2]", "[1,2", "[[1],[2,3"} {
+		if _, _, err := parsePacket(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+
+	if _, err := parse(strings.NewReader("[1,[2\n[1]\n")); err == nil {
+		t.Error("expected an error for a packet without an end")
+	}
+}
+
 func Test_compareItem(t *testing.T) {
 	type args struct {
 		a Value
@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	"bufio"
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
+	"github.com/pimvanhespen/aoc2022/pkg/render"
+	"image"
+	"image/color"
 	"io"
 	"math"
 	"strings"
@@ -104,6 +107,19 @@ func (f Field) String() string {
 	return string(bytes.Join(bts, []byte{'\n'}))
 }
 
+var sandText = render.Text{
+	Colors: map[rune]color.Color{
+		'#': color.RGBA{0x80, 0x80, 0x80, 0xff},
+		'o': color.RGBA{0xe0, 0xc0, 0x60, 0xff},
+	},
+	Cell: 3,
+}
+
+// Image draws the rocks and the sand of the field.
+func (f Field) Image() image.Image {
+	return sandText.Image(string(bytes.Join(f.field, []byte{'\n'})))
+}
+
 func (f Field) isAir(pos Vector) bool {
 	if pos.X < 0 || pos.X >= len(f.field[0]) || pos.Y < 0 || pos.Y >= len(f.field) {
 		return true
@@ -180,33 +196,45 @@ func (l Line) String() string {
 	return fmt.Sprintf("%v -> %v", l.From, l.To)
 }
 
-func parse(reader io.Reader) Field {
+func parse(reader io.Reader) (Field, error) {
 	f := NewField()
 
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		txt := scanner.Text()
-		lines := parseLine(txt)
+		lines, err := parseLine(txt)
+		if err != nil {
+			return Field{}, err
+		}
 		f.Add(lines)
 	}
 
-	return f.Compile()
+	if err := scanner.Err(); err != nil {
+		return Field{}, err
+	}
+
+	return f.Compile(), nil
 }
 
-func parseLine(s string) []Line {
+func parseLine(s string) ([]Line, error) {
 	parts := strings.Split(s, " -> ")
-	vecs := list.Transform(parts, func(t string) Vector {
+	vecs, err := list.TransformErr(parts, func(t string) (Vector, error) {
 		var x, y int
-		_, _ = fmt.Sscanf(t, "%d,%d", &x, &y)
-		return Vector{x, y}
+		if _, err := fmt.Sscanf(t, "%d,%d", &x, &y); err != nil {
+			return Vector{}, fmt.Errorf("invalid point %q: %w", t, err)
+		}
+		return Vector{x, y}, nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	lines := make([]Line, 0, len(vecs)-1)
 	for i := 0; i < len(vecs)-1; i++ {
 		lines = append(lines, NewLine(vecs[i], vecs[i+1]))
 	}
 
-	return lines
+	return lines, nil
 }
 
 // --- helpers
@@ -227,18 +255,8 @@ func min(a, b int) int {
 
 // -- execution
 
-func main() {
-	reader, err := aoc.Get(14)
-	if err != nil {
-		panic(err)
-	}
-	f := parse(reader)
-
-	r1 := solve1(f.Copy())
-	fmt.Println("Part 1:", r1)
-
-	r2 := solve2(f.Copy())
-	fmt.Println("Part 2:", r2)
+func init() {
+	aoc.Register(14, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)).WithRender(render1))
 }
 
 func solve1(f Field) int {
@@ -246,10 +264,25 @@ func solve1(f Field) int {
 	for f.SimulateSandDrop() {
 		total++
 	}
-	fmt.Println(f)
 	return total
 }
 
+// render1 records the sand falling in part 1 as an animation.
+func render1(c *aoc.Client, f Field) error {
+	rec := render.Recorder{
+		Palette:  sandText.Palette(),
+		Skip:     10,
+		Hold:     300,
+		MaxWidth: 600,
+	}
+
+	for f.SimulateSandDrop() {
+		rec.AddFunc(f.Image)
+	}
+
+	return rec.Save(c, 14, "sand_1.gif")
+}
+
 func solve2(f Field) int {
 
 	// expand the field to the left and right
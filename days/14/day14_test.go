@@ -0,0 +1,56 @@
+package day14
+
+import (
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"strings"
+	"testing"
+)
+
+const testInput = `498,4 -> 498,6 -> 496,6
+503,4 -> 502,4 -> 502,9 -> 494,9`
+
+func TestSolve1(t *testing.T) {
+	const want = 24
+	field, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := solve1(field)
+	if got != want {
+		t.Errorf("got != want: got %d, want %d", got, want)
+	}
+}
+
+func TestSolve2(t *testing.T) {
+	const want = 93
+	field, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := solve2(field)
+	if got != want {
+		t.Errorf("got != want: got %d, want %d", got, want)
+	}
+}
+
+func TestRender1(t *testing.T) {
+	field, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &aoc.Client{Dir: t.TempDir()}
+	if err = render1(c, field); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := c.Outputs(14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 1 || outputs[0].Name != "sand_1.gif" {
+		t.Errorf("got outputs %+v, want sand_1.gif", outputs)
+	}
+}
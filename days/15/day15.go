@@ -1,7 +1,6 @@
-package main
+package day15
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
@@ -67,38 +66,27 @@ func Max(a, b int) int {
 	return b
 }
 
-func main() {
-	r, err := aoc.Get(15)
-	if err != nil {
-		panic(err)
-	}
-
-	sensors := parse(r)
-
-	part1 := solve1(sensors, 2_000_000)
-	fmt.Println("Part1:", part1)
-
-	part2 := solve2(sensors, 0, 4_000_000)
-	fmt.Println("Part2:", part2)
+func init() {
+	aoc.Register(15, aoc.NewSolution(parse, aoc.Infallible(part1), part2))
 }
 
-func parse(reader io.Reader) []Sensor {
+func part1(sensors []Sensor) int {
+	return solve1(sensors, 2_000_000)
+}
 
-	var sensors []Sensor
+func part2(sensors []Sensor) (int, error) {
+	return solve2(sensors, 0, 4_000_000)
+}
 
-	scanner := bufio.NewScanner(reader)
+var sensorPattern = aoc.MustPattern[Sensor]("Sensor at x={Position.X}, y={Position.Y}: closest beacon is at x={NearestBeacon.X}, y={NearestBeacon.Y}")
 
-	for scanner.Scan() {
-		text := scanner.Text()
-		if text == "" {
-			continue
-		}
-		var b Sensor
-		_, _ = fmt.Sscanf(text, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d", &b.Position.X, &b.Position.Y, &b.NearestBeacon.X, &b.NearestBeacon.Y)
-		sensors = append(sensors, b)
+func parse(reader io.Reader) ([]Sensor, error) {
+	p := aoc.Parser[Sensor]{
+		SkipEmptyLines: true,
+		ParseFn:        sensorPattern.Parse,
 	}
 
-	return sensors
+	return p.Rows(reader)
 }
 
 func solve1(sensors []Sensor, y int) int {
@@ -158,7 +146,7 @@ func merge(coverages [][2]int) [][2]int {
 	return coverages
 }
 
-func solve2(sensors []Sensor, minC, maxC int) int {
+func solve2(sensors []Sensor, minC, maxC int) (int, error) {
 
 	var coverages [][2]int
 
@@ -180,11 +168,11 @@ func solve2(sensors []Sensor, minC, maxC int) int {
 		coverages = merge(coverages)
 
 		if len(coverages) <= 0 {
-			panic("no coverage")
+			return 0, fmt.Errorf("no coverage at y=%d", y)
 		}
 
 		if len(coverages) >= 3 {
-			panic("too many coverages")
+			return 0, fmt.Errorf("too many coverages at y=%d", y)
 		}
 
 		if len(coverages) == 1 {
@@ -193,7 +181,7 @@ func solve2(sensors []Sensor, minC, maxC int) int {
 			}
 
 			if coverages[Low][Low] > minC && coverages[Low][High] < maxC {
-				panic("coverage is too small")
+				return 0, fmt.Errorf("coverage is too small at y=%d", y)
 			}
 
 			// we have a single coverage that does not cover the whole range
@@ -211,5 +199,9 @@ func solve2(sensors []Sensor, minC, maxC int) int {
 		}
 	}
 
-	return y + x*4_000_000
+	if y > maxC {
+		return 0, fmt.Errorf("no uncovered position found")
+	}
+
+	return y + x*4_000_000, nil
 }
@@ -1,4 +1,4 @@
-package main
+package day15
 
 import (
 	"bytes"
@@ -25,7 +25,10 @@ Sensor at x=20, y=1: closest beacon is at x=15, y=3`
 
 func TestSolve1(t *testing.T) {
 	const want = 26
-	sensors := parse(strings.NewReader(testInput))
+	sensors, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	printMap(sensors)
 
@@ -37,9 +40,15 @@ func TestSolve1(t *testing.T) {
 
 func TestSolve2(t *testing.T) {
 	const want = 56000011
-	sensors := parse(strings.NewReader(testInput))
+	sensors, err := parse(strings.NewReader(testInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	got := solve2(sensors, 0, 20)
+	got, err := solve2(sensors, 0, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Errorf("solve2() = %d, want %d", got, want)
 	}
@@ -1,13 +1,11 @@
-package main
+package day16
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/set"
 	"io"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -67,19 +65,20 @@ type Branch struct {
 	Total   int
 }
 
-func (b Branch) Extend(valve *Valve, limit int) Branch {
+func (b Branch) Extend(valve *Valve, limit int) (Branch, error) {
 	if valve == nil {
-		panic("valve is nil")
+		return Branch{}, fmt.Errorf("valve is nil")
+	}
+
+	dist, ok := b.current.Dist[valve]
+	if !ok {
+		return Branch{}, fmt.Errorf("valve %s can not be reached from %s", valve.Name, b.current.Name)
 	}
 
 	ns := b.Open.Clone()
 	ns.Add(valve)
 
-	totalSteps := 1 + b.Steps + b.current.Dist[valve]
-
-	if totalSteps <= b.Steps {
-		panic("total steps is equal to steps")
-	}
+	totalSteps := 1 + b.Steps + dist
 
 	flow := valve.FlowRate * (limit - totalSteps)
 
@@ -89,7 +88,7 @@ func (b Branch) Extend(valve *Valve, limit int) Branch {
 		History: append(b.History, NewStep(valve, totalSteps)),
 		Steps:   totalSteps,
 		Total:   b.Total + flow,
-	}
+	}, nil
 }
 
 func (b Branch) String() string {
@@ -104,10 +103,10 @@ func (b Branch) String() string {
 	return sb.String()
 }
 
-func BranchOut(usefulValves *set.Set[*Valve], cb Branch, limit int) []Branch {
+func BranchOut(usefulValves *set.Set[*Valve], cb Branch, limit int) ([]Branch, error) {
 
 	if cb.Steps > limit {
-		panic("too many steps")
+		return nil, fmt.Errorf("branch takes %d steps, more than the limit of %d", cb.Steps, limit)
 	}
 
 	inRange := func(v *Valve) bool {
@@ -118,43 +117,48 @@ func BranchOut(usefulValves *set.Set[*Valve], cb Branch, limit int) []Branch {
 	todo := usefulValves.Difference(cb.Open).Filter(inRange)
 
 	if todo.Len() == 0 {
-		return []Branch{cb}
+		return []Branch{cb}, nil
 	}
 
 	var branches []Branch
 
 	for _, v := range todo.ToSlice() {
-		branch := cb.Extend(v, limit)
-		branches = append(branches, BranchOut(usefulValves, branch, limit)...)
+		branch, err := cb.Extend(v, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		sub, err := BranchOut(usefulValves, branch, limit)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, sub...)
 	}
 
-	return branches
+	return branches, nil
 }
 
-func main() {
-	r, err := aoc.Get(16)
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	aoc.Register(16, aoc.NewSolution(parseStart, solve1, solve2))
+}
 
+// parseStart parses the input and returns the valve named AA, where the search starts.
+func parseStart(r io.Reader) (*Valve, error) {
 	root, err := parse(r)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	var aa *Valve
 	for v := range root.Dist {
 		if v.Name == "AA" {
-			aa = v
-			break
+			return v, nil
 		}
 	}
 
-	fmt.Println(solve1(aa))
-	fmt.Println(solve2(aa))
+	return nil, fmt.Errorf("valve AA not found")
 }
 
-func solve1(root *Valve) int {
+func solve1(root *Valve) (int, error) {
 
 	initial := Branch{
 		current: root,
@@ -168,7 +172,10 @@ func solve1(root *Valve) int {
 		}
 	}
 
-	branches := BranchOut(usefulValves, initial, 30)
+	branches, err := BranchOut(usefulValves, initial, 30)
+	if err != nil {
+		return 0, err
+	}
 
 	max := 0
 	for _, b := range branches {
@@ -178,10 +185,10 @@ func solve1(root *Valve) int {
 		}
 	}
 
-	return max
+	return max, nil
 }
 
-func solve2(root *Valve) int {
+func solve2(root *Valve) (int, error) {
 	initial := Branch{
 		current: root,
 		Open:    set.New[*Valve](),
@@ -194,7 +201,10 @@ func solve2(root *Valve) int {
 		}
 	}
 
-	branches := BranchOut(usefulValves, initial, 26)
+	branches, err := BranchOut(usefulValves, initial, 26)
+	if err != nil {
+		return 0, err
+	}
 
 	sort.Slice(branches, func(i, j int) bool {
 		return branches[i].Total > branches[j].Total
@@ -218,7 +228,27 @@ func solve2(root *Valve) int {
 		}
 	}
 
-	return max
+	return max, nil
+}
+
+// valveLine is a line of the scan of the valves.
+type valveLine struct {
+	Name     string
+	FlowRate int
+	Tunnels  []string
+}
+
+var (
+	valvesPattern = aoc.MustPattern[valveLine]("Valve {Name} has flow rate={FlowRate}; tunnels lead to valves {Tunnels:, }")
+	valvePattern  = aoc.MustPattern[valveLine]("Valve {Name} has flow rate={FlowRate}; tunnel leads to valve {Tunnels}")
+)
+
+// parseValveLine parses a line of the scan, valves with a single tunnel are described in singular form.
+func parseValveLine(line string) (valveLine, error) {
+	if strings.Contains(line, "; tunnel leads") {
+		return valvePattern.Parse(line)
+	}
+	return valvesPattern.Parse(line)
 }
 
 func parse(reader io.Reader) (*Valve, error) {
@@ -227,51 +257,29 @@ func parse(reader io.Reader) (*Valve, error) {
 	vm := map[string]*Valve{}
 	vs := map[string][]string{}
 
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		text := scanner.Text()
-		if len(text) == 0 {
-			continue
-		}
-
-		parts := strings.Split(text, "; ")
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid line: %q", text)
-		}
-
-		name := parts[0][6:8]
-		flowRateString := parts[0][strings.Index(parts[0], "=")+1:]
+	p := aoc.Parser[valveLine]{
+		SkipEmptyLines: true,
+		ParseFn:        parseValveLine,
+	}
 
-		flowRate, err := strconv.Atoi(flowRateString)
-		if err != nil {
-			return nil, fmt.Errorf("invalid flow rate: %q", flowRateString)
-		}
+	lines, err := p.Rows(reader)
+	if err != nil {
+		return nil, err
+	}
 
+	for _, line := range lines {
 		v := &Valve{
-			Name:     name,
-			FlowRate: flowRate,
+			Name:     line.Name,
+			FlowRate: line.FlowRate,
 		}
 
-		vm[name] = v
+		vm[line.Name] = v
 
 		if root == nil {
 			root = v
 		}
 
-		var valves []string
-		firstComma := strings.IndexRune(parts[1], ',')
-		if firstComma == -1 {
-			valve := parts[1][strings.LastIndex(parts[1], " ")+1:]
-			valves = append(valves, valve)
-		} else {
-			valves = strings.Split(parts[1][firstComma-2:], ", ")
-		}
-
-		vs[name] = valves
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read input: %w", err)
+		vs[line.Name] = line.Tunnels
 	}
 
 	for name, tunnels := range vs {
@@ -1,4 +1,4 @@
-package main
+package day16
 
 import (
 	"fmt"
@@ -24,7 +24,10 @@ func TestSolve1(t *testing.T) {
 	}
 
 	const want = 1651
-	got := solve1(v)
+	got, err := solve1(v)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Errorf("got %d, want %d", got, want)
 	}
@@ -37,7 +40,10 @@ func TestSolve2(t *testing.T) {
 	}
 
 	const want = 1707
-	got := solve2(v)
+	got, err := solve2(v)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Errorf("got %d, want %d", got, want)
 	}
@@ -67,12 +73,28 @@ func TestSolveBounds(t *testing.T) {
 	}
 
 	const want = 0
-	got := solve1(a)
+	got, err := solve1(a)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Errorf("got %d, want %d", got, want)
 	}
 }
 
+func TestSolve1_unreachable(t *testing.T) {
+	v, err := parse(strings.NewReader(`Valve AA has flow rate=0; tunnel leads to valve BB
+Valve BB has flow rate=5; tunnel leads to valve CC
+Valve CC has flow rate=3; tunnel leads to valve CC`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := solve1(v); err == nil {
+		t.Error("expected an error for a valve that can not be reached")
+	}
+}
+
 func PrintValves(v *Valve, indent string, m map[*Valve]struct{}) {
 	if m == nil {
 		m = map[*Valve]struct{}{}
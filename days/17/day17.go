@@ -1,27 +1,22 @@
-package main
+package day17
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
 	"strings"
 )
 
-func main() {
-	r, err := aoc.Get(17)
-	if err != nil {
-		panic(err)
-	}
-
-	input, err := parse(r)
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	aoc.Register(17, aoc.NewSolution(parse, aoc.Infallible(part1), aoc.Infallible(part2)))
+}
 
+func part1(input Jets) int {
 	heights := solve2(input, 2022)
-	fmt.Println("Part 1:", heights[0])
+	return heights[0]
+}
 
+func part2(input Jets) int {
 	const rocks = 1000000000000
 
 	initialRocks, initialHeight := 1729, 2666    // measured by hand from the output of part 1
@@ -36,15 +31,12 @@ func main() {
 	remainingRocks := (rocks - initialRocks) % rockerPerCycle
 
 	vals := solve2(input, initialRocks+remainingRocks)
-	fmt.Println(vals)
 
 	diffH := vals[0] - initialHeight
-	fmt.Println("diffH", diffH)
 
 	resultHeight += diffH
 
-	fmt.Println("Part 2:", resultHeight)
-
+	return resultHeight
 }
 
 type Jets struct {
@@ -415,8 +407,6 @@ func solve2(input Jets, blocks int) []int {
 				if cycle == 0 {
 					iFallen, iHeight = rps, hps
 				}
-				fmt.Println("CYCLE", cycle)
-				fmt.Println(rps, hps)
 
 				lastHeight = field.height
 				lastFallen = len(field.blocks)
@@ -1,12 +1,12 @@
-package main
+package day18
 
 import (
 	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"github.com/pimvanhespen/aoc2022/pkg/datastructs/queue"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/set"
 	"io"
-	"log"
 	"math"
 )
 
@@ -44,17 +44,8 @@ func (b Block) String() string {
 	return fmt.Sprintf("%v; %t", b.Pos, b.isLava)
 }
 
-func main() {
-	r, err := aoc.Get(18)
-	if err != nil {
-		log.Fatal(err)
-	}
-	v, err := parse(r)
-	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println(solve1(v))
-	fmt.Println(solve2(v))
+func init() {
+	aoc.Register(18, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
 }
 
 func parse(reader io.Reader) ([]Vector3D, error) {
@@ -154,17 +145,18 @@ func solve2(v []Vector3D) int {
 
 	lava := set.New[*Block]()
 	seen := set.New[*Block]()
-	todo := set.New[*Block]()
+	var todo queue.Deque[*Block]
 
-	todo.Add(b[0][0][0]) // begin with one of the outer air blocks
+	// begin with one of the outer air blocks
+	todo.PushBack(b[0][0][0])
+	seen.Add(b[0][0][0])
 
 	// we loop through all directly connected air blocks, if a direct block is not an air block
 	// it is an outer-layer lava block. We want to know which lava blocks are on the outside, and which air block are
 	// connected to these lava blocks. By only checking outer air blocks, it is impossible to find any air blocks within
 	// the structure.
-	for !todo.IsEmpty() {
-		block := todo.Pop()
-		seen.Add(block)
+	for todo.Len() > 0 {
+		block := todo.PopFront()
 		for _, n := range block.Neighbours() {
 			if n.isLava {
 				lava.Add(n)
@@ -173,7 +165,8 @@ func solve2(v []Vector3D) int {
 			if seen.Contains(n) {
 				continue
 			}
-			todo.Add(n)
+			seen.Add(n)
+			todo.PushBack(n)
 		}
 	}
 
@@ -1,67 +1,48 @@
-package main
+package day19
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
 	"io"
-	"time"
 )
 
-func main() {
-	blueprints, err := aoc.Load(19, parse)
-	if err != nil {
-		panic(err)
-	}
-
-	// Part 1
-	start := time.Now()
-	res := solve1(blueprints)
-	lap := time.Since(start)
-	fmt.Println("Part 1:", res, "in", lap)
-
-	// Part 2
-	start = time.Now()
-	res2 := solve2(blueprints[:3])
-	lap = time.Since(start)
-	fmt.Println("Part 2:", res2, "in", lap)
+func init() {
+	aoc.Register(19, aoc.NewSolution(parse, aoc.Infallible(solve1), part2))
+}
 
+// part2 only uses the first three blueprints, the elephants ate the others.
+func part2(blueprints []Blueprint) (int, error) {
+	if len(blueprints) < 3 {
+		return 0, fmt.Errorf("expected at least 3 blueprints, got %d", len(blueprints))
+	}
+	return solve2(blueprints[:3]), nil
 }
 
-func parse(reader io.Reader) ([]Blueprint, error) {
-	const format = `Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.`
-	var nr, oreOre, clayOre, obsidianOre, obsidianClay, geodeOre, geodeObsidian int
-	var blueprints []Blueprint
+var blueprintPattern = aoc.MustPattern[Blueprint](`Blueprint {ID}: Each ore robot costs {Ore.Ore} ore. Each clay robot costs {Clay.Ore} ore. Each obsidian robot costs {Obsidian.Ore} ore and {Obsidian.Clay} clay. Each geode robot costs {Geode.Ore} ore and {Geode.Obsidian} obsidian.`)
 
-	scanner := bufio.NewScanner(reader)
+func parse(reader io.Reader) ([]Blueprint, error) {
+	p := aoc.Parser[Blueprint]{
+		SkipEmptyLines: true,
+		ParseFn:        parseBlueprint,
+	}
 
-	for scanner.Scan() {
-		text := scanner.Text()
-		if text == "" {
-			continue
-		}
+	return p.Rows(reader)
+}
 
-		_, err := fmt.Sscanf(text, format, &nr, &oreOre, &clayOre, &obsidianOre, &obsidianClay, &geodeOre, &geodeObsidian)
-		if err != nil {
-			return nil, err
-		}
+func parseBlueprint(line string) (Blueprint, error) {
+	b, err := blueprintPattern.Parse(line)
+	if err != nil {
+		return Blueprint{}, err
+	}
 
-		blueprints = append(blueprints, Blueprint{
-			ID:       nr,
-			Ore:      Resources{Ore: oreOre},
-			Clay:     Resources{Ore: clayOre},
-			Obsidian: Resources{Ore: obsidianOre, Clay: obsidianClay},
-			Geode:    Resources{Ore: geodeOre, Obsidian: geodeObsidian},
-			Max: Resources{
-				Ore:      max(oreOre, max(clayOre, max(obsidianOre, geodeOre))),
-				Clay:     obsidianClay,
-				Obsidian: geodeObsidian,
-			},
-		})
+	b.Max = Resources{
+		Ore:      max(b.Ore.Ore, max(b.Clay.Ore, max(b.Obsidian.Ore, b.Geode.Ore))),
+		Clay:     b.Obsidian.Clay,
+		Obsidian: b.Geode.Obsidian,
 	}
 
-	return blueprints, nil
+	return b, nil
 }
 
 func solve1(blueprints []Blueprint) int {
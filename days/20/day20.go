@@ -0,0 +1,78 @@
+package day20
+
+import (
+	"bufio"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"github.com/pimvanhespen/aoc2022/pkg/datastructs/list"
+	"io"
+	"strconv"
+)
+
+func init() {
+	aoc.Register(20, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
+}
+
+func parse(reader io.Reader) ([]int, error) {
+	scanner := bufio.NewScanner(reader)
+	var ints []int
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		num, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, num)
+	}
+
+	return ints, nil
+}
+
+func solve1(input []int) int {
+	return solve(input, 1, 1)
+}
+
+func solve(input []int, key int, times int) int {
+	c := list.NewIndexedLoop[int]()
+
+	// the nodes keep the original order of the numbers
+	nodes := make([]*list.Node[int], len(input))
+	for i, v := range input {
+		nodes[i] = c.InsertAt(i, v*key)
+	}
+
+	mix(c, nodes, times)
+
+	return getCoords(c)
+}
+
+func solve2(input []int) int {
+	const decryptionKey = 811589153
+	return solve(input, decryptionKey, 10)
+}
+
+// mix moves every number by its value, in the original order.
+func mix(c *list.IndexedLoop[int], nodes []*list.Node[int], times int) {
+	for iter := 0; iter < times; iter++ {
+		for _, n := range nodes {
+			c.Move(n, n.Value)
+		}
+	}
+}
+
+func getCoords(c *list.IndexedLoop[int]) int {
+	var zero int
+	c.Each(func(i int, n *list.Node[int]) bool {
+		zero = i
+		return n.Value != 0
+	})
+
+	var sum int
+	for i := 1; i <= 3; i++ {
+		sum += c.At((zero + i*1000) % c.Size()).Value
+	}
+
+	return sum
+}
@@ -0,0 +1,276 @@
+package day21
+
+import (
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+	"strings"
+)
+
+func init() {
+	aoc.Register(21, aoc.NewSolution(parse, solve1, solve2))
+}
+
+type Row struct {
+	Name    string
+	Left    string
+	Right   string
+	Op      byte
+	Value   int
+	IsValue bool
+}
+
+var (
+	valuePattern     = aoc.MustPattern[Row]("{Name}: {Value}")
+	operationPattern = aoc.MustPattern[operation]("{Name}: {Left} {Op} {Right}")
+)
+
+// operation is a monkey that yells the result of a math operation.
+type operation struct {
+	Name, Left, Op, Right string
+}
+
+func parse(reader io.Reader) ([]Row, error) {
+	p := aoc.Parser[Row]{
+		SkipEmptyLines: true,
+		ParseFn:        parseRow,
+	}
+
+	return p.Rows(reader)
+}
+
+func parseRow(line string) (Row, error) {
+	if strings.Count(line, " ") == 1 {
+		row, err := valuePattern.Parse(line)
+		row.IsValue = true
+		return row, err
+	}
+
+	op, err := operationPattern.Parse(line)
+	if err != nil {
+		return Row{}, err
+	}
+
+	if len(op.Op) != 1 || !strings.Contains("+-*/", op.Op) {
+		return Row{}, aoc.ErrorAt(len(op.Name)+len(op.Left)+4, fmt.Errorf("invalid operator %q", op.Op))
+	}
+
+	return Row{
+		Name:  op.Name,
+		Left:  op.Left,
+		Right: op.Right,
+		Op:    op.Op[0],
+	}, nil
+}
+
+type MathNode struct {
+	Name    string
+	Left    *MathNode
+	Right   *MathNode
+	Op      rune
+	value   int
+	isValue bool
+}
+
+func (m MathNode) IsValue() bool {
+	return m.isValue
+}
+
+func (m MathNode) String() string {
+	if m.IsValue() {
+		return fmt.Sprintf("%s: %d", m.Name, m.value)
+	}
+	return fmt.Sprintf("%s: %s %c %s", m.Name, m.Left.Name, m.Op, m.Right.Name)
+}
+
+func (m MathNode) Value() (int, error) {
+	if m.IsValue() {
+		return m.value, nil
+	}
+
+	if m.Left == nil || m.Right == nil {
+		return 0, fmt.Errorf("monkey %s does not yell", m.Name)
+	}
+
+	left, err := m.Left.Value()
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := m.Right.Value()
+	if err != nil {
+		return 0, err
+	}
+
+	switch m.Op {
+	case '*':
+		return left * right, nil
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '/':
+		if right == 0 {
+			return 0, fmt.Errorf("monkey %s divides by zero", m.Name)
+		}
+		return left / right, nil
+	}
+
+	return 0, fmt.Errorf("invalid op of monkey %s: %q", m.Name, m.Op)
+}
+
+func rowsToMap(input []Row) map[string]*MathNode {
+	nodes := make(map[string]*MathNode)
+
+	for _, row := range input {
+
+		var node *MathNode
+		if n, ok := nodes[row.Name]; ok {
+			node = n
+		} else {
+			node = &MathNode{Name: row.Name}
+			nodes[row.Name] = node
+		}
+
+		if row.IsValue {
+			node.value = int(row.Value)
+			node.isValue = true
+			continue
+		} else {
+			node.Op = rune(row.Op)
+
+			var left *MathNode
+			if n, ok := nodes[row.Left]; ok {
+				left = n
+			} else {
+				left = &MathNode{Name: row.Left}
+				nodes[row.Left] = left
+			}
+
+			var right *MathNode
+			if n, ok := nodes[row.Right]; ok {
+				right = n
+			} else {
+				right = &MathNode{Name: row.Right}
+				nodes[row.Right] = right
+			}
+
+			node.Left = left
+			node.Right = right
+		}
+	}
+
+	return nodes
+}
+
+func solve1(input []Row) (int, error) {
+
+	nodes := rowsToMap(input)
+
+	root, ok := nodes["root"]
+	if !ok {
+		return 0, fmt.Errorf("root not found")
+	}
+
+	return root.Value()
+}
+
+func recursive(node *MathNode, target *MathNode, path []*MathNode) []*MathNode {
+
+	path = append(path, node)
+
+	if node == target {
+		return path
+	}
+
+	if node.IsValue() {
+		return nil
+	}
+
+	left := recursive(node.Left, target, path)
+	if left != nil {
+		return left
+	}
+
+	return recursive(node.Right, target, path)
+}
+
+func solve2(input []Row) (int, error) {
+	nodes := rowsToMap(input)
+
+	root, ok := nodes["root"]
+	if !ok {
+		return 0, fmt.Errorf("root not found")
+	}
+
+	// find branch leading to human
+	human, ok := nodes["humn"]
+	if !ok {
+		return 0, fmt.Errorf("human not found")
+	}
+
+	path := recursive(root, human, nil)
+	if path == nil {
+		return 0, fmt.Errorf("no path found from root to human")
+	}
+
+	var required int
+
+	for idx, node := range path[:len(path)-1] {
+
+		var opposite int
+		var err error
+
+		isLeft := node.Left == path[idx+1] // +1 for child, +1 for begin offset of path
+		if isLeft {
+			opposite, err = node.Right.Value()
+		} else {
+			opposite, err = node.Left.Value()
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if idx == 0 {
+			// set initial required value to equal whatever the root node has on the opposite side
+			required = opposite
+			continue
+		}
+
+		required, err = reverse(required, node.Op, opposite, isLeft)
+		if err != nil {
+			return 0, fmt.Errorf("monkey %s: %w", node.Name, err)
+		}
+	}
+
+	return required, nil
+}
+
+func reverse(sum int, op rune, value int, isLeft bool) (int, error) {
+	switch op {
+	case '*':
+		if value == 0 {
+			return 0, fmt.Errorf("can not reverse a multiplication by zero")
+		}
+		return sum / value, nil
+
+	case '+':
+		return sum - value, nil
+
+	case '-':
+		if isLeft {
+			return sum + value, nil
+		}
+		return value - sum, nil
+
+	case '/':
+		if isLeft {
+			return sum * value, nil
+		}
+		if sum == 0 {
+			return 0, fmt.Errorf("can not reverse a division resulting in zero")
+		}
+		return value / sum, nil
+	}
+	return 0, fmt.Errorf("invalid op: %q", op)
+}
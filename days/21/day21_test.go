@@ -1,4 +1,4 @@
-package main
+package day21
 
 import (
 	"strings"
@@ -29,7 +29,10 @@ func TestSolve1(t *testing.T) {
 
 	const want = 152
 
-	res := solve1(input)
+	res, err := solve1(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if res != want {
 		t.Errorf("solve1() = %v, want %v", res, want)
 	}
@@ -43,12 +46,32 @@ func TestSolve2(t *testing.T) {
 
 	const want = 301
 
-	res := solve2(input)
+	res, err := solve2(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if res != want {
 		t.Errorf("solve2() = %v, want %v", res, want)
 	}
 }
 
+func TestSolve1_missingMonkey(t *testing.T) {
+	input, err := parse(strings.NewReader("root: aaaa + bbbb\naaaa: 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := solve1(input); err == nil {
+		t.Error("expected an error for a monkey that does not yell")
+	}
+}
+
+func Test_parseRow_invalidOp(t *testing.T) {
+	if _, err := parseRow("root: aaaa % bbbb"); err == nil {
+		t.Error("expected an error for an invalid operator")
+	}
+}
+
 func Test_reverse(t *testing.T) {
 	type args struct {
 		sum    int
@@ -144,7 +167,11 @@ func Test_reverse(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := reverse(tt.args.sum, tt.args.op, tt.args.value, tt.args.isLeft); got != tt.want {
+			got, err := reverse(tt.args.sum, tt.args.op, tt.args.value, tt.args.isLeft)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
 				t.Errorf("reverse() = %v, want %v", got, tt.want)
 			}
 		})
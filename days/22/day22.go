@@ -1,4 +1,4 @@
-package main
+package day22
 
 import (
 	"bufio"
@@ -11,34 +11,42 @@ import (
 	"strings"
 )
 
-func main() {
-	r, err := aoc.Get(22)
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	aoc.Register(22, aoc.NewSolution[Input, int, int](parseInput, part1, nil))
+}
+
+// Input is the map of the board with the path to follow.
+type Input struct {
+	Field *Field
+	Steps []Step
+}
 
+func parseInput(r io.Reader) (Input, error) {
 	field, steps, err := parse(r)
 	if err != nil {
-		panic(err)
+		return Input{}, err
 	}
+	return Input{Field: field, Steps: steps}, nil
+}
 
-	r1 := solve1(field.Copy(), steps)
-	fmt.Println("Part 1:", r1)
+func part1(in Input) (int, error) {
+	return solve1(in.Field, in.Steps)
 }
 
-func solve1(field *Field, steps []Step) int {
+func solve1(field *Field, steps []Step) (int, error) {
 
 	begin := field.start
 	direction := Right
 
 	err := field.JoinEdges(joinSimple)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 
-	place, history := executeRoute(begin, direction, steps)
-
-	fmt.Println(plotMoves(field, history))
+	place, history, err := executeRoute(begin, direction, steps)
+	if err != nil {
+		return 0, err
+	}
 
 	return calcScore1(place, history)
 }
@@ -59,7 +67,10 @@ func parseField(data [][]byte) (*Field, error) {
 				t = TileType(c)
 			}
 
-			tile := NewTile(t, pos)
+			tile, err := NewTile(t, pos)
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", y+1, x+1, err)
+			}
 
 			// initial connections
 			if left, ok := field.tiles[pos.Add(Left)]; ok {
@@ -75,6 +86,10 @@ func parseField(data [][]byte) (*Field, error) {
 			field.register(tile)
 		}
 	}
+
+	if field.start == nil {
+		return nil, fmt.Errorf("no tiles found")
+	}
 	return field, nil
 }
 
@@ -160,7 +175,7 @@ func parseSteps(bts []byte) ([]Step, error) {
 	return steps, nil
 }
 
-func executeRoute(place *Tile, direction Vector2D, steps []Step) (*Tile, map[Vector2D]Vector2D) {
+func executeRoute(place *Tile, direction Vector2D, steps []Step) (*Tile, map[Vector2D]Vector2D, error) {
 
 	history := make(map[Vector2D]Vector2D)
 	history[place.Pos] = direction
@@ -168,7 +183,10 @@ func executeRoute(place *Tile, direction Vector2D, steps []Step) (*Tile, map[Vec
 	for _, s := range steps {
 		if s.isMove {
 			for i := 0; i < s.distance; i++ {
-				next := place.Move(direction)
+				next, err := place.Move(direction)
+				if err != nil {
+					return nil, nil, err
+				}
 				if next.Type == Wall {
 					break
 				}
@@ -176,12 +194,16 @@ func executeRoute(place *Tile, direction Vector2D, steps []Step) (*Tile, map[Vec
 				history[place.Pos] = direction
 			}
 		} else {
-			direction = Rotate(direction, s.rotateClockwise)
+			var err error
+			direction, err = Rotate(direction, s.rotateClockwise)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 		history[place.Pos] = direction
 	}
 
-	return place, history
+	return place, history, nil
 }
 
 func joinSimple(field *Field) error {
@@ -238,11 +260,17 @@ func joinSimple(field *Field) error {
 	}
 
 	for i := 0; i < len(tops); i++ {
+		if tops[i] == nil || bottoms[i] == nil {
+			return fmt.Errorf("no tiles found for x=%d", i)
+		}
 		tops[i].Top = bottoms[i]
 		bottoms[i].Bottom = tops[i]
 	}
 
 	for i := 0; i < len(lefts); i++ {
+		if lefts[i] == nil || rights[i] == nil {
+			return fmt.Errorf("no tiles found for y=%d", i)
+		}
 		lefts[i].Left = rights[i]
 		rights[i].Right = lefts[i]
 	}
@@ -250,7 +278,7 @@ func joinSimple(field *Field) error {
 	return nil
 }
 
-func calcScore1(t *Tile, history map[Vector2D]Vector2D) int {
+func calcScore1(t *Tile, history map[Vector2D]Vector2D) (int, error) {
 
 	var score int
 
@@ -259,7 +287,7 @@ func calcScore1(t *Tile, history map[Vector2D]Vector2D) int {
 
 	direction, ok := history[t.Pos]
 	if !ok {
-		panic("no direction for last step")
+		return 0, fmt.Errorf("no direction for last step")
 	}
 
 	switch direction {
@@ -272,7 +300,7 @@ func calcScore1(t *Tile, history map[Vector2D]Vector2D) int {
 	case Up:
 		score += 3
 	}
-	return score
+	return score, nil
 }
 
 type TileType byte
@@ -296,9 +324,9 @@ func (t *Tile) String() string {
 	return fmt.Sprintf("%c", t.Type)
 }
 
-func NewTile(t TileType, pos Vector2D) *Tile {
-	if t == Invalid {
-		panic("invalid tile type")
+func NewTile(t TileType, pos Vector2D) (*Tile, error) {
+	if t != Floor && t != Wall {
+		return nil, fmt.Errorf("invalid tile type %q", byte(t))
 	}
 	return &Tile{
 		Top:    nil,
@@ -307,25 +335,32 @@ func NewTile(t TileType, pos Vector2D) *Tile {
 		Right:  nil,
 		Pos:    pos,
 		Type:   t,
-	}
+	}, nil
 }
 
-func (t Tile) Move(direction Vector2D) *Tile {
+func (t Tile) Move(direction Vector2D) (*Tile, error) {
+	var next *Tile
 	switch direction {
 	case Up:
-		return t.Top
+		next = t.Top
 	case Down:
-		return t.Bottom
+		next = t.Bottom
 	case Left:
-		return t.Left
+		next = t.Left
 	case Right:
-		return t.Right
+		next = t.Right
+	default:
+		return nil, fmt.Errorf("invalid direction %v", direction)
+	}
+
+	if next == nil {
+		return nil, fmt.Errorf("no tile next to %v in direction %v", t.Pos, direction)
 	}
-	panic("invalid direction")
+	return next, nil
 }
 
 func (t Tile) Copy() *Tile {
-	return NewTile(t.Type, t.Pos)
+	return &Tile{Pos: t.Pos, Type: t.Type}
 }
 
 type Field struct {
@@ -405,30 +440,30 @@ func (v Vector2D) Add(v2 Vector2D) Vector2D {
 	return Vector2D{v.X + v2.X, v.Y + v2.Y}
 }
 
-func Rotate(v Vector2D, clockwise bool) Vector2D {
+func Rotate(v Vector2D, clockwise bool) (Vector2D, error) {
 	switch v {
 	case Up:
 		if clockwise {
-			return Right
+			return Right, nil
 		}
-		return Left
+		return Left, nil
 	case Right:
 		if clockwise {
-			return Down
+			return Down, nil
 		}
-		return Up
+		return Up, nil
 	case Down:
 		if clockwise {
-			return Left
+			return Left, nil
 		}
-		return Right
+		return Right, nil
 	case Left:
 		if clockwise {
-			return Up
+			return Up, nil
 		}
-		return Down
+		return Down, nil
 	}
-	panic("invalid direction")
+	return Vector2D{}, fmt.Errorf("invalid direction %v", v)
 }
 
 type Step struct {
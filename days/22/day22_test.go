@@ -1,4 +1,4 @@
-package main
+package day22
 
 import (
 	"reflect"
@@ -27,7 +27,12 @@ func TestSolve1(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if got, want := solve1(field.Copy(), path), 6032; got != want {
+	got, err := solve1(field.Copy(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 6032; got != want {
 		t.Errorf("solve1() = %d, want %d", got, want)
 	}
 }
@@ -65,3 +70,9 @@ func TestParseSteps(t *testing.T) {
 	}
 
 }
+
+func TestParse_invalidTile(t *testing.T) {
+	if _, _, err := parse(strings.NewReader("..\n.x\n\n1R1")); err == nil {
+		t.Error("expected an error for an invalid tile")
+	}
+}
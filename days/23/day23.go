@@ -1,8 +1,7 @@
-package main
+package day23
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"io"
 	"math"
@@ -268,15 +267,6 @@ func solve2(field *Field) int {
 	return 1 + rounds
 }
 
-func main() {
-	field, err := aoc.Load[*Field](23, parse)
-	if err != nil {
-		panic(err)
-	}
-
-	part1 := solve1(field.Copy())
-	fmt.Println("part1:", part1)
-
-	part2 := solve2(field.Copy())
-	fmt.Println("part2:", part2)
+func init() {
+	aoc.Register(23, aoc.NewSolution(parse, aoc.Infallible(solve1), aoc.Infallible(solve2)))
 }
@@ -1,8 +1,7 @@
-package main
+package day24
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/pimvanhespen/aoc2022/pkg/aoc"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/queue"
 	"github.com/pimvanhespen/aoc2022/pkg/datastructs/set"
@@ -92,11 +91,6 @@ type State struct {
 	Position Vector2D
 }
 
-// Equals returns true if the given state is equal to the current state
-func (s State) Equals(o State) bool {
-	return s == o
-}
-
 // Moves returns all possible moves from the current state
 func (s *State) Moves() []State {
 	return []State{
@@ -146,15 +140,8 @@ var (
 	West  = Vector2D{-1, 0}
 )
 
-func main() {
-	valley, err := aoc.Load[Valley](24, parse)
-	if err != nil {
-		panic(err)
-	}
-
-	part1 := solve1(valley)
-
-	fmt.Println("Part 1:", part1)
+func init() {
+	aoc.Register(24, aoc.NewSolution[Valley, int, int](parse, aoc.Infallible(solve1), nil))
 }
 
 func parse(reader io.Reader) (Valley, error) {
@@ -218,7 +205,20 @@ func parse(reader io.Reader) (Valley, error) {
 	return valley, nil
 }
 
+// stateQueue is the priority queue of the search, the priorities never decrease,
+// so a bucket queue can be used.
+type stateQueue interface {
+	Insert(State, int)
+	Pop() State
+	Len() int
+}
+
 func solve1(valley Valley) int {
+	return search(valley, queue.NewBucket[State](queue.Dial))
+}
+
+// search returns the minute at which the finish is reached, using q to order the states.
+func search(valley Valley, q stateQueue) int {
 
 	// initial state
 	startTile := NewVector2D(0, -1)                      // start at the top
@@ -231,8 +231,6 @@ func solve1(valley Valley) int {
 
 	seen := set.New[State]()
 
-	// create a new priority queue
-	q := queue.NewPriority[State]()
 	q.Insert(start, 0)
 
 	// for each minute, move all blizzards and check all moves
@@ -261,7 +259,7 @@ func solve1(valley Valley) int {
 
 			// register the move as seen
 			seen.Add(move)
-			q.Upsert(move, mScore)
+			q.Insert(move, mScore)
 		}
 	}
 
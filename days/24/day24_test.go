@@ -1,8 +1,10 @@
-package main
+package day24
 
 import (
 	"bytes"
 	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aocbench"
+	"github.com/pimvanhespen/aoc2022/pkg/datastructs/queue"
 	"strings"
 	"testing"
 )
@@ -81,6 +83,43 @@ func TestSolve1(t *testing.T) {
 	}
 }
 
+func BenchmarkSearch(b *testing.B) {
+	queues := []struct {
+		name string
+		new  func() stateQueue
+	}{
+		{"heap", func() stateQueue { return queue.NewHeap[State, int](queue.Min) }},
+		{"dial", func() stateQueue { return queue.NewBucket[State](queue.Dial) }},
+		{"radix", func() stateQueue { return queue.NewBucket[State](queue.Radix) }},
+	}
+
+	inputs := []struct {
+		name  string
+		input func(b *testing.B) []byte
+	}{
+		{"example", func(b *testing.B) []byte { return []byte(testInput) }},
+		{"input", func(b *testing.B) []byte { return aocbench.CachedInput(b, "../..", 24) }},
+	}
+
+	for _, in := range inputs {
+		b.Run(in.name, func(b *testing.B) {
+			valley, err := parse(bytes.NewReader(in.input(b)))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for _, q := range queues {
+				b.Run(q.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						search(valley, q.new())
+					}
+				})
+			}
+		})
+	}
+}
+
 var example = `Initial state:
 #E######
 #>>.<^<#
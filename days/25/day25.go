@@ -1,4 +1,4 @@
-package main
+package day25
 
 import (
 	"bufio"
@@ -9,19 +9,18 @@ import (
 	"math"
 )
 
-func main() {
-	nums, err := aoc.Load(25, parse)
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	aoc.Register(25, aoc.NewSolution[[]Snafu, Snafu, Snafu](parse, aoc.Infallible(sum), nil))
+}
 
-	var sum int
+// sum returns the sum of all numbers, day 25 has no second part.
+func sum(nums []Snafu) Snafu {
+	var total int
 	for _, num := range nums {
-		sum += num.decimal
+		total += num.decimal
 	}
 
-	fmt.Println(sum)
-	fmt.Println("Part 1:", Snafu{decimal: sum})
+	return Snafu{decimal: total}
 }
 
 func parse(reader io.Reader) ([]Snafu, error) {
@@ -67,6 +66,8 @@ func ParseSNAFU(input string) (Snafu, error) {
 			n = 1
 		case '2':
 			n = 2
+		default:
+			return Snafu{}, aoc.ErrorAt(i+1, fmt.Errorf("invalid SNAFU digit %q", c))
 		}
 
 		power := len(input) - 1 - i
@@ -92,9 +93,13 @@ func snafuLimit(power int) int {
 var log10of5 = math.Log10(5)
 
 func (s Snafu) String() string {
+	if s.decimal == 0 {
+		return "0"
+	}
+
 	var target = float64(s.decimal)
 
-	limit := math.Ceil(math.Log10(target) / log10of5)
+	limit := math.Ceil(math.Log10(math.Abs(target)) / log10of5)
 
 	var chars []rune
 
@@ -130,18 +135,9 @@ func (s Snafu) String() string {
 	return string(chars)
 }
 
+// snafuDigits are the SNAFU digits, from -2 to 2.
+const snafuDigits = "=-012"
+
 func toRune(n int) rune {
-	switch n {
-	case -2:
-		return '='
-	case -1:
-		return '-'
-	case 0:
-		return '0'
-	case 1:
-		return '1'
-	case 2:
-		return '2'
-	}
-	panic("invalid rune")
+	return rune(snafuDigits[n+2])
 }
@@ -1,4 +1,4 @@
-package main
+package day25
 
 import (
 	"fmt"
@@ -36,6 +36,12 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_invalid(t *testing.T) {
+	if _, err := ParseSNAFU("1=3"); err == nil {
+		t.Error("expected an error for an invalid digit")
+	}
+}
+
 func TestSnafu_String(t *testing.T) {
 	for expected, input := range testInputs {
 		snafu := Snafu{expected}
@@ -46,3 +52,9 @@ func TestSnafu_String(t *testing.T) {
 		}
 	}
 }
+
+func TestSnafu_String_zero(t *testing.T) {
+	if got := (Snafu{}).String(); got != "0" {
+		t.Errorf("snafu.String() = %q, want %q", got, "0")
+	}
+}
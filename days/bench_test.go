@@ -0,0 +1,23 @@
+package days
+
+import (
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aocbench"
+	"testing"
+)
+
+// BenchmarkDays benchmarks parsing and both parts of every registered day on its cached input.
+// Days without a cached input are skipped. Run it with a fixed number of iterations, or use
+// `go run ./cmd/aoc bench` to compare the results with the baseline.
+func BenchmarkDays(b *testing.B) {
+	for _, day := range aoc.Days() {
+		day := day
+		b.Run(fmt.Sprintf("day%02d", day), func(b *testing.B) {
+			input := aocbench.CachedInput(b, root, day)
+
+			s, _ := aoc.Lookup(day)
+			aocbench.BenchmarkSolver(b, s, input)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package days registers the solutions of all days with the aoc runner.
+// Import it for its side effects:
+//
+//	import _ "github.com/pimvanhespen/aoc2022/days"
+package days
+
+import (
+	_ "github.com/pimvanhespen/aoc2022/days/01"
+	_ "github.com/pimvanhespen/aoc2022/days/02"
+	_ "github.com/pimvanhespen/aoc2022/days/03"
+	_ "github.com/pimvanhespen/aoc2022/days/04"
+	_ "github.com/pimvanhespen/aoc2022/days/05"
+	_ "github.com/pimvanhespen/aoc2022/days/06"
+	_ "github.com/pimvanhespen/aoc2022/days/07"
+	_ "github.com/pimvanhespen/aoc2022/days/08"
+	_ "github.com/pimvanhespen/aoc2022/days/09"
+	_ "github.com/pimvanhespen/aoc2022/days/10"
+	_ "github.com/pimvanhespen/aoc2022/days/11"
+	_ "github.com/pimvanhespen/aoc2022/days/12"
+	_ "github.com/pimvanhespen/aoc2022/days/13"
+	_ "github.com/pimvanhespen/aoc2022/days/14"
+	_ "github.com/pimvanhespen/aoc2022/days/15"
+	_ "github.com/pimvanhespen/aoc2022/days/16"
+	_ "github.com/pimvanhespen/aoc2022/days/17"
+	_ "github.com/pimvanhespen/aoc2022/days/18"
+	_ "github.com/pimvanhespen/aoc2022/days/19"
+	_ "github.com/pimvanhespen/aoc2022/days/20"
+	_ "github.com/pimvanhespen/aoc2022/days/21"
+	_ "github.com/pimvanhespen/aoc2022/days/22"
+	_ "github.com/pimvanhespen/aoc2022/days/23"
+	_ "github.com/pimvanhespen/aoc2022/days/24"
+	_ "github.com/pimvanhespen/aoc2022/days/25"
+)
@@ -0,0 +1,66 @@
+package days
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// root is the root of the repository, relative to the directory of this package.
+const root = ".."
+
+// TestAnswers runs every registered day against its cached input and compares the answers
+// with the ones recorded in answers.json. Days without a cached input or recorded answer are skipped.
+// Record the answers with `go run ./cmd/aoc run -record`.
+func TestAnswers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the full inputs in short mode")
+	}
+
+	answers, err := aoc.LoadAnswers(filepath.Join(root, aoc.AnswersFile))
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no %s, record the answers with: go run ./cmd/aoc run -record", aoc.AnswersFile)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &aoc.Client{Dir: root}
+
+	for _, day := range aoc.Days() {
+		day := day
+		t.Run(fmt.Sprintf("day%02d", day), func(t *testing.T) {
+			input, err := client.Cached(aoc.Day(day))
+			if errors.Is(err, aoc.ErrNotCached) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, _ := aoc.Lookup(day)
+
+			for _, part := range []int{1, 2} {
+				part := part
+				t.Run(fmt.Sprintf("part%d", part), func(t *testing.T) {
+					want, ok := answers.Get(day, part)
+					if !ok {
+						t.Skip("no answer recorded")
+					}
+
+					report := aoc.Run(day, s, part, input)
+					if report.Err != nil {
+						t.Fatal(report.Err)
+					}
+
+					if report.Answer != want {
+						t.Errorf("got %q, want %q", report.Answer, want)
+					}
+				})
+			}
+		})
+	}
+}
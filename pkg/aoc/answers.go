@@ -0,0 +1,89 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// AnswersFile is the default location of the golden answers, relative to the root of the repository.
+// It is not checked in, as the answers belong to the personal puzzle inputs.
+const AnswersFile = "answers.json"
+
+// Answers are the known correct answers, keyed by day and part.
+type Answers map[int]map[int]string
+
+// LoadAnswers reads the answers stored at path. A missing file results in an error that wraps os.ErrNotExist.
+func LoadAnswers(path string) (Answers, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var answers Answers
+	if err = json.Unmarshal(b, &answers); err != nil {
+		return nil, fmt.Errorf("read answers %s: %w", path, err)
+	}
+
+	if answers == nil {
+		answers = make(Answers)
+	}
+
+	return answers, nil
+}
+
+// Get returns the answer of the given part of a day.
+func (a Answers) Get(day, part int) (string, bool) {
+	answer, ok := a[day][part]
+	return answer, ok
+}
+
+// Set records the answer of the given part of a day.
+func (a Answers) Set(day, part int, answer string) {
+	if a[day] == nil {
+		a[day] = make(map[int]string)
+	}
+	a[day][part] = answer
+}
+
+// Save writes the answers to path.
+func (a Answers) Save(path string) error {
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(path, append(b, '\n'))
+}
+
+// ErrNotCached is returned by Cached for inputs that are not downloaded yet.
+var ErrNotCached = errors.New("input is not cached")
+
+// Cached returns the cached input of the given puzzle, without downloading it. It returns an error
+// that wraps ErrCorrupt when the input does not match its metadata.
+func (c *Client) Cached(p Puzzle) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	fp, err := c.inputPath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(fp)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", p, ErrNotCached)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Open downloads inputs that do not match their metadata again, Cached can not
+	if !isCached(fp) {
+		return nil, fmt.Errorf("%s: %w: clear it to download it again", p, ErrCorrupt)
+	}
+
+	return b, nil
+}
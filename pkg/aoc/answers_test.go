@@ -0,0 +1,95 @@
+package aoc
+
+import (
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AnswersFile)
+
+	if _, err := LoadAnswers(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got error %v, want %v", err, os.ErrNotExist)
+	}
+
+	answers := make(Answers)
+	answers.Set(1, 1, "24000")
+	answers.Set(1, 2, "45000")
+	answers.Set(25, 1, "2=-1=0")
+
+	if err := answers.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		day, part int
+		want      string
+		ok        bool
+	}{
+		{1, 1, "24000", true},
+		{1, 2, "45000", true},
+		{25, 1, "2=-1=0", true},
+		{25, 2, "", false},
+		{2, 1, "", false},
+	} {
+		got, ok := loaded.Get(tc.day, tc.part)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("day %d part %d: got %q (%v), want %q (%v)", tc.day, tc.part, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestClient_Cached(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	if _, err := c.Cached(Day(1)); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("got error %v, want %v", err, ErrNotCached)
+	}
+
+	r, err := c.Get(Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := c.Cached(Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != fixtureInput {
+		t.Errorf("got %q, want %q", b, fixtureInput)
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestClient_Cached_corrupt(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// truncate the input, its metadata still has the full size
+	fp := filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")
+	if err := os.WriteFile(fp, []byte(fixtureInput[:5]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Cached(Day(1)); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("got error %v, want %v", err, ErrCorrupt)
+	}
+}
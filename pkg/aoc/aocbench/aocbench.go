@@ -0,0 +1,68 @@
+// Package aocbench provides the helpers for the benchmarks of the days.
+// It is only meant to be imported by tests, so the aoc package does not depend on package testing.
+package aocbench
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"testing"
+)
+
+// BenchmarkSolver runs the sub-benchmarks parse, part1 and part2 of the solver on the input.
+// Parts may modify their input, so the input is parsed again before every iteration of a part.
+// The timer is stopped while parsing, so the metrics of the parts only cover the part itself,
+// like the reports of aoc.Run.
+func BenchmarkSolver(b *testing.B, s aoc.Solver, input []byte) {
+	b.Run("parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := s.Parse(bytes.NewReader(input)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	parts := []func(any) (any, error){s.Part1, s.Part2}
+
+	for i, part := range parts {
+		part := part
+		b.Run(fmt.Sprintf("part%d", i+1), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				parsed, err := s.Parse(bytes.NewReader(input))
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+
+				_, err = part(parsed)
+
+				if errors.Is(err, aoc.ErrNotSolved) {
+					b.Skip(err)
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// CachedInput returns the cached input of the day, with dir as the root of the repository.
+// It skips when the input is not cached.
+func CachedInput(tb testing.TB, dir string, day int) []byte {
+	tb.Helper()
+
+	input, err := (&aoc.Client{Dir: dir}).Cached(aoc.Day(day))
+	if errors.Is(err, aoc.ErrNotCached) {
+		tb.Skip(err)
+	}
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return input
+}
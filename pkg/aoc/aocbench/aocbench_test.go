@@ -0,0 +1,58 @@
+package aocbench
+
+import (
+	"errors"
+	"flag"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"io"
+	"testing"
+)
+
+type benchSolver struct {
+	parses, solves int
+}
+
+func (s *benchSolver) Parse(r io.Reader) (any, error) {
+	s.parses++
+	b, err := io.ReadAll(r)
+	return []byte(b), err
+}
+
+func (s *benchSolver) Part1(input any) (any, error) {
+	b := input.([]byte)
+	if b[0] != 'x' {
+		return nil, errors.New("part 1 got a modified input")
+	}
+	b[0] = 'y'
+	s.solves++
+	return len(b), nil
+}
+
+func (s *benchSolver) Part2(any) (any, error) {
+	return nil, aoc.ErrNotSolved
+}
+
+func TestBenchmarkSolver(t *testing.T) {
+	// a fixed number of iterations keeps the test fast
+	benchtime := flag.Lookup("test.benchtime")
+	defer func(v string) {
+		_ = benchtime.Value.Set(v)
+	}(benchtime.Value.String())
+	if err := benchtime.Value.Set("20x"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &benchSolver{}
+
+	res := testing.Benchmark(func(b *testing.B) {
+		BenchmarkSolver(b, s, []byte("xyz"))
+	})
+
+	if res.N == 0 {
+		t.Fatal("benchmark did not run")
+	}
+	// every iteration of part 1 gets a fresh input, or it would fail
+	if s.solves == 0 || s.parses <= s.solves {
+		t.Errorf("got %d parses for %d solves", s.parses, s.solves)
+	}
+}
@@ -0,0 +1,318 @@
+// Package aoctest provides a local stand-in for the Advent of Code website,
+// so code that downloads puzzle inputs can be tested without network access.
+package aoctest
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Session is the session cookie accepted by a Server created with NewServer.
+	// It has the format of a real session cookie.
+	Session = "53616c7465645f5f616f63746573742d73657373696f6e2d616f63746573742d73657373696f6e2d616f637465737400"
+
+	msgLogin    = "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n"
+	msgIdentify = "To play, please identify yourself via one of these services."
+	msgNotFound = "404 Not Found\n"
+
+	msgCorrect     = "That's the right answer!  You are one gold star closer to saving your vacation."
+	msgTooHigh     = "That's not the right answer; your answer is too high.  Please wait one minute before trying again."
+	msgTooLow      = "That's not the right answer; your answer is too low.  Please wait one minute before trying again."
+	msgWrong       = "That's not the right answer.  Please wait one minute before trying again."
+	msgWrongLevel  = "You don't seem to be solving the right level.  Did you already complete it?"
+	msgTooRecently = "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait."
+)
+
+// Server serves puzzle inputs from a fixtures directory. The files of a day are read from
+// <dir>/<year>/<day>/, with the day formatted as two digits, e.g. testdata/2022/01/input.txt:
+//   - input.txt is the puzzle input.
+//   - puzzle.html is the puzzle page.
+//   - answers.txt holds the answer of part 1 on the first line and the answer of part 2 on the second.
+//
+// Private leaderboards are read from <dir>/<year>/leaderboard/<id>.json.
+type Server struct {
+	*httptest.Server
+
+	dir     string
+	session string
+
+	mu         sync.Mutex
+	requests   []string
+	userAgents []string
+	solved     map[string]int
+	cooldown   time.Duration
+	blocked    time.Time
+	failures   int
+	failStatus int
+}
+
+// NewServer starts a Server that serves the fixtures in dir to clients that present the given session cookie.
+// The caller should call Close when finished, to shut it down.
+func NewServer(dir, session string) *Server {
+	s := &Server{
+		dir:     dir,
+		session: session,
+		solved:  make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Dir returns the fixtures directory of the server.
+func (s *Server) Dir() string {
+	return s.dir
+}
+
+// SetCooldown sets the time a client has to wait after submitting a wrong answer.
+// Submissions within the cooldown are rejected as too recent. Zero disables the cooldown, which is the default.
+func (s *Server) SetCooldown(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cooldown = d
+}
+
+// FailNext makes the server respond to the next n requests with the given status code,
+// e.g. http.StatusBadGateway to test the handling of server errors.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = n
+	s.failStatus = status
+}
+
+// UserAgents returns the User-Agent headers of all requests the server received, in order.
+func (s *Server) UserAgents() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.userAgents...)
+}
+
+// Requests returns the paths of all requests the server received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.Path)
+	s.userAgents = append(s.userAgents, r.UserAgent())
+	fail := s.failures > 0
+	if fail {
+		s.failures--
+	}
+	status := s.failStatus
+	s.mu.Unlock()
+
+	if fail {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if year, id, ok := parseLeaderboardPath(r.URL.Path); ok {
+		s.serveLeaderboard(w, r, year, id)
+		return
+	}
+	if year, ok := parsePrivatePath(r.URL.Path); ok {
+		s.servePrivate(w, year)
+		return
+	}
+
+	year, day, resource, ok := parseDayPath(r.URL.Path)
+	if !ok {
+		http.Error(w, msgNotFound, http.StatusNotFound)
+		return
+	}
+
+	// like the website, only inputs are refused, pages and answers ask to log in with status 200
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != s.session {
+		if resource == "input" {
+			http.Error(w, msgLogin, http.StatusBadRequest)
+		} else {
+			writeArticle(w, msgIdentify)
+		}
+		return
+	}
+
+	dayDir := filepath.Join(s.dir, strconv.Itoa(year), fmt.Sprintf("%02d", day))
+
+	switch {
+	case resource == "" && r.Method == http.MethodGet:
+		s.serveFile(w, filepath.Join(dayDir, "puzzle.html"), "text/html; charset=utf-8")
+	case resource == "input" && r.Method == http.MethodGet:
+		s.serveFile(w, filepath.Join(dayDir, "input.txt"), "text/plain")
+	case resource == "answer" && r.Method == http.MethodPost:
+		s.serveAnswer(w, r, dayDir)
+	default:
+		http.Error(w, msgNotFound, http.StatusNotFound)
+	}
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, path, contentType string) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		http.Error(w, msgNotFound, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(b)
+}
+
+func (s *Server) serveAnswer(w http.ResponseWriter, r *http.Request, dayDir string) {
+	b, err := os.ReadFile(filepath.Join(dayDir, "answers.txt"))
+	if err != nil {
+		http.Error(w, msgNotFound, http.StatusNotFound)
+		return
+	}
+	answers := strings.Split(strings.TrimSpace(string(b)), "\n")
+
+	level, err := strconv.Atoi(r.PostFormValue("level"))
+	if err != nil || level < 1 || level > len(answers) {
+		http.Error(w, "invalid level", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeArticle(w, s.judge(dayDir, level, strings.TrimSpace(answers[level-1]), r.PostFormValue("answer")))
+}
+
+// judge returns the message for the given answer, the caller must hold s.mu.
+func (s *Server) judge(dayDir string, level int, want, got string) string {
+	if wait := time.Until(s.blocked); wait > 0 {
+		return fmt.Sprintf(msgTooRecently, formatWait(wait))
+	}
+
+	if s.solved[dayDir]+1 != level {
+		return msgWrongLevel
+	}
+
+	if got == want {
+		s.solved[dayDir] = level
+		return msgCorrect
+	}
+
+	s.blocked = time.Now().Add(s.cooldown)
+
+	wantN, wErr := strconv.Atoi(want)
+	gotN, gErr := strconv.Atoi(got)
+
+	switch {
+	case wErr != nil || gErr != nil:
+		return msgWrong
+	case gotN > wantN:
+		return msgTooHigh
+	default:
+		return msgTooLow
+	}
+}
+
+// serveLeaderboard serves the JSON of a private leaderboard. Like the website, it redirects clients
+// without access to the overview of private leaderboards.
+func (s *Server) serveLeaderboard(w http.ResponseWriter, r *http.Request, year int, id string) {
+	fp := filepath.Join(s.dir, strconv.Itoa(year), "leaderboard", id+".json")
+
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != s.session || !fileExists(fp) {
+		http.Redirect(w, r, fmt.Sprintf("/%d/leaderboard/private", year), http.StatusFound)
+		return
+	}
+
+	s.serveFile(w, fp, "application/json")
+}
+
+func (s *Server) servePrivate(w http.ResponseWriter, year int) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body><main>\n<article><p>Private leaderboards of %d.</p></article>\n</main></body></html>\n", year)
+}
+
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func writeArticle(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body><main>\n<article><p>%s</p></article>\n</main></body></html>\n", html.EscapeString(msg))
+}
+
+// formatWait formats a wait like the Advent of Code website does, e.g. 1m 5s.
+func formatWait(d time.Duration) string {
+	secs := int(d.Round(time.Second) / time.Second)
+	if secs < 60 {
+		return fmt.Sprintf("%ds", secs)
+	}
+	return fmt.Sprintf("%dm %ds", secs/60, secs%60)
+}
+
+// parseDayPath parses paths of the form /<year>/day/<day> and /<year>/day/<day>/<resource>.
+func parseDayPath(path string) (year, day int, resource string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[1] != "day" {
+		return 0, 0, "", false
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, "", false
+	}
+
+	day, err = strconv.Atoi(parts[2])
+	if err != nil || day < 1 || day > 25 {
+		return 0, 0, "", false
+	}
+
+	if len(parts) == 4 {
+		resource = parts[3]
+	}
+
+	return year, day, resource, true
+}
+
+// parseLeaderboardPath parses paths of the form /<year>/leaderboard/private/view/<id>.json.
+func parseLeaderboardPath(path string) (year int, id string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 5 || parts[1] != "leaderboard" || parts[2] != "private" || parts[3] != "view" {
+		return 0, "", false
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", false
+	}
+
+	id = strings.TrimSuffix(parts[4], ".json")
+	if id == parts[4] || id == "" || strings.ContainsAny(id, `./\`) {
+		return 0, "", false
+	}
+
+	return year, id, true
+}
+
+// parsePrivatePath parses paths of the form /<year>/leaderboard/private.
+func parsePrivatePath(path string) (year int, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 3 || parts[1] != "leaderboard" || parts[2] != "private" {
+		return 0, false
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	return year, err == nil
+}
@@ -0,0 +1,143 @@
+package aoc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaselinePath is the default location of the benchmark baseline.
+const BaselinePath = ".aoc/bench.json"
+
+// Benchmark metrics, as reported by go test -bench with -benchmem.
+const (
+	NsPerOp     = "ns/op"
+	BytesPerOp  = "B/op"
+	AllocsPerOp = "allocs/op"
+)
+
+// procsRegex matches the GOMAXPROCS suffix of benchmark names.
+var procsRegex = regexp.MustCompile(`-\d+$`)
+
+// BenchResult is a single result of a benchmark.
+type BenchResult struct {
+	Name string `json:"name"`
+	N    int    `json:"n"`
+	// Values holds the measurements by metric, e.g. NsPerOp.
+	Values map[string]float64 `json:"values"`
+}
+
+// Baseline is a set of benchmark results.
+type Baseline struct {
+	Created time.Time `json:"created"`
+	// Config holds the configuration lines of the output, such as goos, goarch and cpu.
+	Config  map[string]string `json:"config,omitempty"`
+	Results []BenchResult     `json:"results"`
+}
+
+// ParseBenchmarks parses the output of go test -bench. Lines that are not results are ignored,
+// except for the configuration lines. The GOMAXPROCS suffix is removed from the names.
+func ParseBenchmarks(r io.Reader) (*Baseline, error) {
+	bl := &Baseline{Created: time.Now().UTC(), Config: make(map[string]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if key, value, ok := strings.Cut(line, ": "); ok && !strings.ContainsAny(key, " \t") {
+			if key != "pkg" {
+				bl.Config[key] = strings.TrimSpace(value)
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || len(fields)%2 != 0 {
+			continue
+		}
+
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+
+		res := BenchResult{
+			Name:   procsRegex.ReplaceAllString(fields[0], ""),
+			N:      n,
+			Values: make(map[string]float64),
+		}
+
+		for i := 2; i < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid benchmark line %q: %w", line, err)
+			}
+			res.Values[fields[i+1]] = v
+		}
+
+		bl.Results = append(bl.Results, res)
+	}
+
+	return bl, scanner.Err()
+}
+
+// LoadBaseline reads the baseline stored at path.
+func LoadBaseline(path string) (*Baseline, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bl Baseline
+	if err = json.Unmarshal(b, &bl); err != nil {
+		return nil, fmt.Errorf("read baseline %s: %w", path, err)
+	}
+
+	return &bl, nil
+}
+
+// Save stores the baseline at path.
+func (bl *Baseline) Save(path string) error {
+	b, err := json.MarshalIndent(bl, "", "  ")
+	if err != nil {
+		return err
+	}
+	return replaceFile(path, append(b, '\n'))
+}
+
+// Merge adds the results of the benchmarks of old that are not in the baseline,
+// to update a part of the benchmarks of a baseline.
+func (bl *Baseline) Merge(old *Baseline) {
+	names := make(map[string]bool)
+	for _, r := range bl.Results {
+		names[r.Name] = true
+	}
+
+	for _, r := range old.Results {
+		if !names[r.Name] {
+			bl.Results = append(bl.Results, r)
+		}
+	}
+}
+
+// Samples returns the values of the metric of every benchmark, by name, in order of appearance.
+func (bl *Baseline) Samples(metric string) (names []string, samples map[string][]float64) {
+	samples = make(map[string][]float64)
+	for _, r := range bl.Results {
+		v, ok := r.Values[metric]
+		if !ok {
+			continue
+		}
+		if _, seen := samples[r.Name]; !seen {
+			names = append(names, r.Name)
+		}
+		samples[r.Name] = append(samples[r.Name], v)
+	}
+	return names, samples
+}
@@ -0,0 +1,77 @@
+package aoc
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const benchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/pimvanhespen/aoc2022/days
+cpu: AMD EPYC 7B13
+BenchmarkDays/day01/parse-8         	      10	     52310 ns/op	   41720 B/op	    2270 allocs/op
+BenchmarkDays/day01/part1-8         	      10	       803.0 ns/op	       0 B/op	       0 allocs/op
+--- SKIP: BenchmarkDays/day02
+    bench_test.go:22: 2022 day 2: input is not cached
+BenchmarkDays/day01/parse-8         	      10	     50100 ns/op	   41720 B/op	    2270 allocs/op
+PASS
+ok  	github.com/pimvanhespen/aoc2022/days	0.412s
+`
+
+func TestParseBenchmarks(t *testing.T) {
+	bl, err := ParseBenchmarks(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantConfig := map[string]string{"goos": "linux", "goarch": "amd64", "cpu": "AMD EPYC 7B13"}
+	if !reflect.DeepEqual(bl.Config, wantConfig) {
+		t.Errorf("got config %v, want %v", bl.Config, wantConfig)
+	}
+
+	want := []BenchResult{
+		{Name: "BenchmarkDays/day01/parse", N: 10, Values: map[string]float64{NsPerOp: 52310, BytesPerOp: 41720, AllocsPerOp: 2270}},
+		{Name: "BenchmarkDays/day01/part1", N: 10, Values: map[string]float64{NsPerOp: 803, BytesPerOp: 0, AllocsPerOp: 0}},
+		{Name: "BenchmarkDays/day01/parse", N: 10, Values: map[string]float64{NsPerOp: 50100, BytesPerOp: 41720, AllocsPerOp: 2270}},
+	}
+	if !reflect.DeepEqual(bl.Results, want) {
+		t.Errorf("got results %+v, want %+v", bl.Results, want)
+	}
+
+	names, samples := bl.Samples(NsPerOp)
+	if !reflect.DeepEqual(names, []string{"BenchmarkDays/day01/parse", "BenchmarkDays/day01/part1"}) {
+		t.Errorf("got names %q", names)
+	}
+	if got := samples["BenchmarkDays/day01/parse"]; !reflect.DeepEqual(got, []float64{52310, 50100}) {
+		t.Errorf("got samples %v", got)
+	}
+}
+
+func TestBaseline_Save(t *testing.T) {
+	bl, err := ParseBenchmarks(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fp := filepath.Join(t.TempDir(), BaselinePath)
+	if err = bl.Save(fp); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadBaseline(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Results, bl.Results) || !got.Created.Equal(bl.Created) {
+		t.Errorf("got %+v, want %+v", got, bl)
+	}
+
+	// merging keeps the results of the benchmarks that did not run again
+	cur := &Baseline{Results: []BenchResult{{Name: "BenchmarkDays/day01/parse", N: 10, Values: map[string]float64{NsPerOp: 1}}}}
+	cur.Merge(got)
+	if len(cur.Results) != 2 || cur.Results[1].Name != "BenchmarkDays/day01/part1" {
+		t.Errorf("got merged results %+v", cur.Results)
+	}
+}
@@ -0,0 +1,244 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// significance is the p-value below which a delta is reported as significant.
+const significance = 0.05
+
+// Summary summarizes the samples of a metric of a benchmark.
+type Summary struct {
+	N    int
+	Mean float64
+	// Spread is the largest deviation from the mean, relative to the mean.
+	Spread float64
+}
+
+// Comparison compares a metric of a benchmark between an old and a new baseline.
+type Comparison struct {
+	Name     string
+	Metric   string
+	Old, New Summary
+	// Delta is the relative change of the mean, it is NaN when the benchmark is missing from either baseline.
+	Delta float64
+	// P is the p-value of the Mann-Whitney U test, it is NaN when there are too few samples.
+	P float64
+}
+
+// Significant reports whether the delta is statistically significant.
+func (c Comparison) Significant() bool {
+	return !math.IsNaN(c.P) && c.P < significance
+}
+
+// Compare compares the metrics of the benchmarks in the current baseline with the old one, like benchstat.
+// Benchmarks are ordered by their first appearance in the current baseline. Benchmarks that only
+// appear in the old baseline are left out, so a part of the benchmarks can be compared.
+func Compare(old, cur *Baseline) []Comparison {
+	var comparisons []Comparison
+
+	for _, metric := range []string{NsPerOp, BytesPerOp, AllocsPerOp} {
+		_, oldSamples := old.Samples(metric)
+		names, newSamples := cur.Samples(metric)
+
+		for _, name := range names {
+			a, b := oldSamples[name], newSamples[name]
+			c := Comparison{
+				Name:   name,
+				Metric: metric,
+				Old:    summarize(a),
+				New:    summarize(b),
+				Delta:  math.NaN(),
+				P:      math.NaN(),
+			}
+
+			if len(a) > 0 && len(b) > 0 {
+				switch {
+				case c.Old.Mean != 0:
+					c.Delta = (c.New.Mean - c.Old.Mean) / c.Old.Mean
+				case c.New.Mean == 0:
+					c.Delta = 0
+				}
+				if len(a) > 1 && len(b) > 1 {
+					c.P = mannWhitneyU(a, b)
+				}
+			}
+
+			comparisons = append(comparisons, c)
+		}
+	}
+
+	return comparisons
+}
+
+// WriteComparisons writes a table per metric, in the format of benchstat. Deltas that are not
+// significant are shown as ~.
+func WriteComparisons(w io.Writer, comparisons []Comparison) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var metric string
+	for _, c := range comparisons {
+		if c.Metric != metric {
+			if metric != "" {
+				_, _ = fmt.Fprintln(tw)
+			}
+			metric = c.Metric
+			_, _ = fmt.Fprintf(tw, "name\told %s\tnew %s\tdelta\n", metric, metric)
+		}
+
+		row := strings.Join([]string{c.Name, formatSummary(c.Metric, c.Old), formatSummary(c.Metric, c.New), formatDelta(c)}, "\t")
+		_, _ = fmt.Fprintln(tw, strings.TrimRight(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func summarize(samples []float64) Summary {
+	s := Summary{N: len(samples)}
+	if s.N == 0 {
+		return s
+	}
+
+	for _, v := range samples {
+		s.Mean += v
+	}
+	s.Mean /= float64(s.N)
+
+	if s.Mean != 0 {
+		for _, v := range samples {
+			s.Spread = math.Max(s.Spread, math.Abs(v-s.Mean)/s.Mean)
+		}
+	}
+
+	return s
+}
+
+func formatSummary(metric string, s Summary) string {
+	if s.N == 0 {
+		return "-"
+	}
+	if s.N == 1 {
+		return formatMetric(metric, s.Mean)
+	}
+	return fmt.Sprintf("%s ± %.0f%%", formatMetric(metric, s.Mean), s.Spread*100)
+}
+
+func formatDelta(c Comparison) string {
+	n := fmt.Sprintf("n=%d+%d", c.Old.N, c.New.N)
+
+	switch {
+	case math.IsNaN(c.Delta):
+		return ""
+	case math.IsNaN(c.P):
+		return fmt.Sprintf("%+.2f%%  (%s)", c.Delta*100, n)
+	case !c.Significant():
+		return fmt.Sprintf("~  (p=%.3f %s)", c.P, n)
+	}
+	return fmt.Sprintf("%+.2f%%  (p=%.3f %s)", c.Delta*100, c.P, n)
+}
+
+// formatMetric formats the value with 3 significant digits and the unit of the metric, e.g. 1.23ms or 4.56kB.
+func formatMetric(metric string, v float64) string {
+	type unit struct {
+		scale  float64
+		suffix string
+	}
+
+	var units []unit
+	switch metric {
+	case NsPerOp:
+		units = []unit{{1e9, "s"}, {1e6, "ms"}, {1e3, "µs"}, {1, "ns"}}
+	case BytesPerOp:
+		units = []unit{{1 << 30, "GB"}, {1 << 20, "MB"}, {1 << 10, "kB"}, {1, "B"}}
+	default:
+		units = []unit{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}, {1, ""}}
+	}
+
+	u := units[len(units)-1]
+	for _, candidate := range units {
+		if math.Abs(v) >= candidate.scale {
+			u = candidate
+			break
+		}
+	}
+
+	scaled := v / u.scale
+	return strconv.FormatFloat(scaled, 'f', decimals(scaled), 64) + u.suffix
+}
+
+// decimals returns the number of decimals to show v with 3 significant digits.
+func decimals(v float64) int {
+	switch v = math.Abs(v); {
+	case v >= 100 || v == 0:
+		return 0
+	case v >= 10:
+		return 1
+	}
+	return 2
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U test of the samples,
+// with the exact distribution of U. Ties count for half.
+func mannWhitneyU(a, b []float64) float64 {
+	var u float64
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case x < y:
+				u++
+			case x == y:
+				u += 0.5
+			}
+		}
+	}
+
+	n1, n2 := len(a), len(b)
+	u = math.Min(u, float64(n1*n2)-u)
+
+	// counts[k] is the number of orderings of the samples with U = k
+	counts := uDistribution(n1, n2)
+
+	var total, tail float64
+	for k, c := range counts {
+		total += c
+		if float64(k) <= math.Ceil(u) {
+			tail += c
+		}
+	}
+
+	return math.Min(1, 2*tail/total)
+}
+
+// uDistribution returns the number of orderings of n1 and n2 samples for every value of U,
+// with the recurrence f(m, n, u) = f(m-1, n, u-n) + f(m, n-1, u).
+func uDistribution(n1, n2 int) []float64 {
+	// f[m][n] is the distribution for m and n samples
+	f := make([][][]float64, n1+1)
+	for m := range f {
+		f[m] = make([][]float64, n2+1)
+		for n := range f[m] {
+			dist := make([]float64, m*n+1)
+			switch {
+			case m == 0 || n == 0:
+				dist[0] = 1
+			default:
+				for u := range dist {
+					if u-n >= 0 && u-n < len(f[m-1][n]) {
+						dist[u] += f[m-1][n][u-n]
+					}
+					if u < len(f[m][n-1]) {
+						dist[u] += f[m][n-1][u]
+					}
+				}
+			}
+			f[m][n] = dist
+		}
+	}
+
+	return f[n1][n2]
+}
@@ -0,0 +1,78 @@
+package aoc
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func baseline(name string, ns ...float64) *Baseline {
+	bl := &Baseline{}
+	for _, v := range ns {
+		bl.Results = append(bl.Results, BenchResult{Name: name, N: 10, Values: map[string]float64{NsPerOp: v, AllocsPerOp: 3}})
+	}
+	return bl
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{"reversed", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		{"equal", []float64{1, 1, 1}, []float64{1, 1, 1}, 1},
+		{"interleaved", []float64{1, 3, 5}, []float64{2, 4, 6}, 0.7},
+	}
+
+	for _, tc := range tests {
+		if got := mannWhitneyU(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: got p=%f, want %f", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := baseline("day01/part1", 100, 101, 99, 100, 100)
+	old.Merge(baseline("day02/part1", 1000))
+	cur := baseline("day01/part1", 50, 51, 49, 50, 50)
+	cur.Merge(baseline("day03/part1", 2000000, 2100000))
+
+	comparisons := Compare(old, cur)
+
+	var ns []Comparison
+	for _, c := range comparisons {
+		if c.Metric == NsPerOp {
+			ns = append(ns, c)
+		}
+	}
+
+	// day02 is left out as it did not run again
+	if len(ns) != 2 || ns[0].Name != "day01/part1" || ns[1].Name != "day03/part1" {
+		t.Fatalf("got comparisons %+v", ns)
+	}
+	if ns[0].Delta != -0.5 || !ns[0].Significant() {
+		t.Errorf("got delta %f with p=%f, want a significant -50%%", ns[0].Delta, ns[0].P)
+	}
+	if !math.IsNaN(ns[1].Delta) {
+		t.Errorf("got delta %f for a new benchmark, want NaN", ns[1].Delta)
+	}
+
+	var sb strings.Builder
+	if err := WriteComparisons(&sb, comparisons); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `name         old ns/op   new ns/op    delta
+day01/part1  100ns ± 1%  50.0ns ± 2%  -50.00%  (p=0.008 n=5+5)
+day03/part1  -           2.05ms ± 2%
+
+name         old allocs/op  new allocs/op  delta
+day01/part1  3.00 ± 0%      3.00 ± 0%      ~  (p=1.000 n=5+5)
+day03/part1  -              3.00 ± 0%
+`
+	if got := sb.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,303 @@
+package aoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// metaSuffix is appended to the path of a cached input to get the path of its metadata.
+const metaSuffix = ".meta.json"
+
+var (
+	// ErrCorrupt is returned by CacheEntry.Verify and Client.Cached when the cached input does not match its metadata.
+	ErrCorrupt = errors.New("cached input is corrupt")
+	// ErrNoMeta is returned by CacheEntry.Verify for inputs that were cached without metadata.
+	ErrNoMeta = errors.New("cached input has no metadata")
+)
+
+// CacheMeta describes a cached input, it is stored next to the input.
+type CacheMeta struct {
+	Fetched time.Time `json:"fetched"`
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"`
+}
+
+// CacheEntry is a cached puzzle input.
+type CacheEntry struct {
+	Puzzle Puzzle
+	Path   string
+	// Meta is nil for inputs that were cached without metadata.
+	Meta *CacheMeta
+	// Legacy is set for inputs that are cached in the legacy days/XX/input.txt layout.
+	Legacy bool
+}
+
+// Cache returns the cached inputs, ordered by year and day. Inputs of the DefaultYear that are
+// cached in the legacy layout are included, after the input of the same day in the current layout.
+func (c *Client) Cache() ([]CacheEntry, error) {
+	dir, err := c.dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := cacheEntries(dir, filepath.Join("inputs", "*", "*", "input.txt"), func(rel string) (p Puzzle, err error) {
+		_, err = fmt.Sscanf(rel, filePathFormat, &p.Year, &p.Day)
+		return p, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	legacy, err := cacheEntries(dir, filepath.Join("days", "*", "input.txt"), func(rel string) (p Puzzle, err error) {
+		p.Year = DefaultYear
+		_, err = fmt.Sscanf(rel, legacyFilePathFormat, &p.Day)
+		return p, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range legacy {
+		legacy[i].Legacy = true
+	}
+	entries = append(entries, legacy...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Puzzle, entries[j].Puzzle
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.Day < b.Day
+	})
+
+	return entries, nil
+}
+
+// cacheEntries returns the inputs in dir that match pattern, parse returns the puzzle of the path
+// of an input relative to dir. Files that are not inputs of a valid puzzle are skipped.
+func cacheEntries(dir, pattern string, parse func(rel string) (Puzzle, error)) ([]CacheEntry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []CacheEntry
+
+	for _, fp := range paths {
+		rel, err := filepath.Rel(dir, fp)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := parse(filepath.ToSlash(rel))
+		if err != nil || p.Validate() != nil {
+			continue
+		}
+
+		meta, err := readMeta(fp)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, CacheEntry{Puzzle: p, Path: fp, Meta: meta})
+	}
+
+	return entries, nil
+}
+
+// Verify checks the cached input against the size and checksum in its metadata.
+func (e CacheEntry) Verify() error {
+	if e.Meta == nil {
+		return fmt.Errorf("%s: %w", e.Puzzle, ErrNoMeta)
+	}
+
+	meta, err := hashFile(e.Path)
+	if err != nil {
+		return err
+	}
+
+	if meta.Size != e.Meta.Size {
+		return fmt.Errorf("%s: %w: size is %d bytes, want %d", e.Puzzle, ErrCorrupt, meta.Size, e.Meta.Size)
+	}
+	if meta.SHA256 != e.Meta.SHA256 {
+		return fmt.Errorf("%s: %w: checksum mismatch", e.Puzzle, ErrCorrupt)
+	}
+
+	return nil
+}
+
+// ClearCache removes the cached input of the given puzzle with its metadata, in both the current
+// and the legacy layout. It returns an error that wraps ErrNotCached when the input is not cached.
+func (c *Client) ClearCache(p Puzzle) error {
+	dir, err := c.dir()
+	if err != nil {
+		return err
+	}
+
+	fp := filepath.Join(dir, fmt.Sprintf(filePathFormat, p.Year, p.Day))
+	paths := []string{fp, fp + metaSuffix}
+
+	if p.Year == DefaultYear {
+		legacy := filepath.Join(dir, fmt.Sprintf(legacyFilePathFormat, p.Day))
+		paths = append(paths, legacy, legacy+metaSuffix)
+	}
+
+	var removed bool
+	for _, path := range paths {
+		err = os.Remove(path)
+		switch {
+		case err == nil:
+			removed = true
+		case !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+
+	if !removed {
+		return fmt.Errorf("%s: %w", p, ErrNotCached)
+	}
+
+	return nil
+}
+
+// isCached reports whether there is a usable input at path. Empty files are never valid inputs,
+// inputs with metadata must match the size and checksum in it.
+func isCached(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || !fi.Mode().IsRegular() || fi.Size() == 0 {
+		return false
+	}
+
+	meta, err := readMeta(path)
+	switch {
+	case err != nil:
+		return false
+	case meta == nil:
+		return true
+	case meta.Size != fi.Size():
+		return false
+	}
+
+	got, err := hashFile(path)
+	return err == nil && got.SHA256 == meta.SHA256
+}
+
+// store writes the input to the cache at path, with its metadata. The input is only stored when r
+// is read completely and has the expected size, which is not checked when it is negative.
+func store(path string, r io.Reader, size int64, fetched time.Time) error {
+	h := sha256.New()
+	meta := CacheMeta{Fetched: fetched.UTC()}
+
+	err := writeFileAtomic(path, func(w io.Writer) error {
+		n, err := io.Copy(io.MultiWriter(w, h), r)
+		if err != nil {
+			return err
+		}
+		if size >= 0 && n != size {
+			return fmt.Errorf("truncated input: got %d of %d bytes", n, size)
+		}
+		if n == 0 {
+			return errors.New("empty input")
+		}
+		meta.Size = n
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	meta.SHA256 = hex.EncodeToString(h.Sum(nil))
+
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(path+metaSuffix, append(b, '\n'))
+}
+
+func readMeta(path string) (*CacheMeta, error) {
+	b, err := os.ReadFile(path + metaSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var meta CacheMeta
+	if err = json.Unmarshal(b, &meta); err != nil {
+		return nil, fmt.Errorf("read metadata of %s: %w", path, err)
+	}
+
+	return &meta, nil
+}
+
+func hashFile(path string) (CacheMeta, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return CacheMeta{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return CacheMeta{}, err
+	}
+
+	return CacheMeta{Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// writeFileAtomic writes a file by writing to a temporary file in the same directory, which is
+// renamed to path once it is complete. The file at path is left untouched when write fails.
+func writeFileAtomic(path string, write func(io.Writer) error) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// replaceFile atomically replaces the file at path with the given contents.
+func replaceFile(path string, b []byte) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+}
@@ -0,0 +1,177 @@
+package aoc
+
+import (
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClient_Cache(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.Cache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+
+	e := entries[0]
+	if e.Puzzle != Day(1) {
+		t.Errorf("got puzzle %v, want %v", e.Puzzle, Day(1))
+	}
+	if e.Meta == nil || e.Meta.Size != int64(len(fixtureInput)) || e.Meta.Fetched.IsZero() {
+		t.Fatalf("got metadata %+v, want the size of the input and the time it was fetched", e.Meta)
+	}
+	if err = e.Verify(); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+
+	if err = os.WriteFile(e.Path, []byte("1000\n2000\n\n4000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = e.Verify(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("got error %v, want %v", err, ErrCorrupt)
+	}
+
+	if err = c.ClearCache(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.ClearCache(Day(1)); !errors.Is(err, ErrNotCached) {
+		t.Errorf("got error %v, want %v", err, ErrNotCached)
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.Dir, "inputs", "2022", "01", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected the cache to be empty, found %v", files)
+	}
+}
+
+func TestClient_Get_truncated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = io.WriteString(w, fixtureInput)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Client{
+		BaseURL:     srv.URL,
+		HTTPClient:  srv.Client(),
+		Session:     aoctest.Session,
+		Dir:         t.TempDir(),
+		MinInterval: -1,
+	}
+
+	if _, err := c.Get(Day(1)); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.Dir, "inputs", "2022", "01", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected nothing to be cached, found %v", files)
+	}
+}
+
+func TestClient_Get_empty(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	fp := filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fp, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.Get(Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != fixtureInput {
+		t.Errorf("got %q, want %q", b, fixtureInput)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected the empty input to be downloaded again, got %d requests", n)
+	}
+}
+
+func TestClient_Cache_legacy(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+
+	legacy := filepath.Join(c.Dir, "days", "05", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.Cache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Puzzle != Day(5) || !entries[0].Legacy || entries[0].Path != legacy {
+		t.Fatalf("got entries %+v, want the legacy input of day 5", entries)
+	}
+	if err = entries[0].Verify(); !errors.Is(err, ErrNoMeta) {
+		t.Errorf("got error %v, want %v", err, ErrNoMeta)
+	}
+
+	if err = c.ClearCache(Day(5)); err != nil {
+		t.Fatal(err)
+	}
+	if existsFile(legacy) {
+		t.Error("expected the legacy input to be removed")
+	}
+}
+
+func TestClient_Get_corrupt(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// same size, other content
+	fp := filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")
+	if err := os.WriteFile(fp, []byte("9999\n2000\n\n3000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.Get(Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != fixtureInput {
+		t.Errorf("got %q, want %q", b, fixtureInput)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("expected the corrupt input to be downloaded again, got %d requests", n)
+	}
+}
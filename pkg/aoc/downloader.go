@@ -1,66 +1,96 @@
 package aoc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
-	urlFormat      = "https://adventofcode.com/2022/day/%d/input"
-	filePathFormat = "days/%02d/input.txt"
-)
-
-func Get(day int) (io.Reader, error) {
+	// DefaultBaseURL is the address of the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
 
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
+	urlFormat      = "%s/%d/day/%d/input"
+	filePathFormat = "inputs/%d/%02d/input.txt"
 
-	fp := fmt.Sprintf(filePathFormat, day)
-
-	fp = filepath.Join(wd, fp)
+	// legacyFilePathFormat is where inputs of the DefaultYear were cached before the cache became year-aware.
+	// These files are still read, see Client.Migrate to copy them to the current layout.
+	legacyFilePathFormat = "days/%02d/input.txt"
+)
 
-	if !existsFile(fp) {
-		err = download(day, fp)
-		if err != nil {
-			return nil, err
-		}
-	}
+// Client retrieves puzzle inputs from the Advent of Code website and caches them on disk.
+// The zero value for Client is ready to use and behaves like DefaultClient.
+type Client struct {
+	// BaseURL is the address of the Advent of Code website, DefaultBaseURL is used when empty.
+	BaseURL string
+	// HTTPClient is used for all requests, http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// Session is the value of the session cookie. When empty, it is provided by Sessions.
+	Session string
+	// Sessions provides the session cookie when Session is empty. When nil, the cookie is read from
+	// the AOC_SESSION environment variable, the user configuration or cookie.txt in Dir, in that order.
+	Sessions SessionProvider
+	// Dir is the directory that holds the input cache, the working directory is used when empty.
+	Dir string
+
+	// UserAgent identifies the client to the website. When empty, it names this repository and the Contact.
+	UserAgent string
+	// Contact is added to the default User-Agent, so the maintainers of the website can reach the user
+	// when the client misbehaves, e.g. an email address. When empty, it is read from $AOC_CONTACT.
+	Contact string
+	// MinInterval is the minimum time between two requests, it is kept between runs.
+	// DefaultMinInterval is used when zero, requests are not throttled when negative.
+	MinInterval time.Duration
+	// Timeout limits the duration of a single request. DefaultTimeout is used when zero, there is no limit when negative.
+	Timeout time.Duration
+	// Retries is the number of times a download is retried after a network or server error.
+	// DefaultRetries is used when zero, downloads are not retried when negative.
+	Retries int
+	// Backoff is the time to wait before the first retry, it doubles on every retry. DefaultBackoff is used when zero.
+	Backoff time.Duration
+
+	mu sync.Mutex
+}
 
-	reader, writer := io.Pipe()
-	go func() {
+// DefaultClient is the Client used by Get and Load.
+var DefaultClient = &Client{}
 
-		file, openErr := os.Open(fp)
-		if openErr != nil {
-			_ = writer.CloseWithError(openErr)
-			return
-		}
-		defer func() {
-			_ = file.Close()
-		}()
-
-		_, cErr := io.Copy(writer, file)
-		if cErr != nil {
-			_ = writer.CloseWithError(cErr)
-			return
-		}
+// Get returns the input of the given puzzle, it is downloaded when it is not cached yet.
+// The caller should close the input, see Open to cancel reading it.
+func (c *Client) Get(p Puzzle) (io.ReadCloser, error) {
+	return c.Open(context.Background(), p)
+}
 
-		_ = writer.Close()
-	}()
+// Get returns the input of the given day of the DefaultYear using the DefaultClient.
+func Get(day int) (io.ReadCloser, error) {
+	return DefaultClient.Get(Day(day))
+}
 
-	return reader, nil
+// GetYear returns the input of the given day and year using the DefaultClient.
+func GetYear(year, day int) (io.ReadCloser, error) {
+	return DefaultClient.Get(Puzzle{Year: year, Day: day})
 }
 
-func Load[Result any](day int, parseFunc func(io.Reader) (Result, error)) (Result, error) {
+// Load parses the input of the given day of the DefaultYear using the DefaultClient.
+func Load[Result any](day int, parseFunc func(io.Reader) (Result, error)) (_ Result, err error) {
 	r, err := Get(day)
 	if err != nil {
 		return *new(Result), err
 	}
 
+	defer func() {
+		cerr := r.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
 	return parseFunc(r)
 }
 
@@ -69,51 +99,110 @@ func existsFile(path string) bool {
 	return err == nil
 }
 
-func getCookie() (_ string, err error) {
-	wd, err := os.Getwd()
+// Migrate copies the inputs that are cached in the legacy days/XX/input.txt layout to the
+// year-aware layout, with their metadata. The legacy files are left in place. It returns the puzzles that were copied.
+func (c *Client) Migrate() (_ []Puzzle, err error) {
+	dir, err := c.dir()
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var migrated []Puzzle
+
+	for day := 1; day <= 25; day++ {
+		p := Day(day)
+
+		src := filepath.Join(dir, fmt.Sprintf(legacyFilePathFormat, day))
+		dst := filepath.Join(dir, fmt.Sprintf(filePathFormat, p.Year, p.Day))
+
+		if !isCached(src) || isCached(dst) {
+			continue
+		}
+
+		if err = migrateFile(src, dst); err != nil {
+			return migrated, fmt.Errorf("migrate %s: %w", p, err)
+		}
+
+		migrated = append(migrated, p)
 	}
 
-	fp := filepath.Join(wd, "cookie.txt")
+	return migrated, nil
+}
 
-	f, err := os.Open(fp)
+// inputPath returns the path of the cached input of the given puzzle. Inputs of the DefaultYear
+// that are only available in the legacy layout are read from there, all others use the current layout.
+func (c *Client) inputPath(p Puzzle) (string, error) {
+	dir, err := c.dir()
 	if err != nil {
 		return "", err
 	}
 
+	fp := filepath.Join(dir, fmt.Sprintf(filePathFormat, p.Year, p.Day))
+	if p.Year != DefaultYear || isCached(fp) {
+		return fp, nil
+	}
+
+	legacy := filepath.Join(dir, fmt.Sprintf(legacyFilePathFormat, p.Day))
+	if isCached(legacy) {
+		return legacy, nil
+	}
+
+	return fp, nil
+}
+
+// migrateFile stores the legacy input at src in the cache at dst, using its modification time
+// as the time it was fetched.
+func migrateFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
 	defer func() {
-		cerr := f.Close()
-		if err == nil {
-			err = cerr
-		}
+		_ = in.Close()
 	}()
 
-	b, err := io.ReadAll(f)
+	fi, err := in.Stat()
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	return store(dst, in, fi.Size(), fi.ModTime())
+}
+
+func (c *Client) dir() (string, error) {
+	if c.Dir != "" {
+		return c.Dir, nil
+	}
+	return os.Getwd()
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
 	}
+	return strings.TrimSuffix(c.BaseURL, "/")
+}
 
-	return string(b), nil
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
 }
 
-func download(day int, destination string) error {
-	remote := fmt.Sprintf(urlFormat, day)
+func (c *Client) download(ctx context.Context, p Puzzle, destination string) (err error) {
+	remote := fmt.Sprintf(urlFormat, c.baseURL(), p.Year, p.Day)
 
-	req, err := http.NewRequest("GET", remote, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", remote, nil)
 	if err != nil {
 		return err
 	}
 
-	cookie, err := getCookie()
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Add("Cookie", fmt.Sprintf("session=%s", cookie))
-
-	resp, err := http.DefaultClient.Do(req)
-
 	defer func() {
 		cerr := resp.Body.Close()
 		if err == nil {
@@ -121,19 +210,9 @@ func download(day int, destination string) error {
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status code %d", resp.StatusCode)
-	}
-
-	f, err := os.Create(destination)
-	if err != nil {
-		return err
-	}
-
-	_, err = io.Copy(f, resp.Body)
-	if err != nil {
+	if err = responseError(resp); err != nil {
 		return err
 	}
 
-	return nil
+	return store(destination, resp.Body, resp.ContentLength, time.Now())
 }
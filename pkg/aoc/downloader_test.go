@@ -0,0 +1,209 @@
+package aoc
+
+import (
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const fixtureInput = "1000\n2000\n\n3000\n"
+
+func newTestClient(t *testing.T, session string) (*Client, *aoctest.Server) {
+	t.Helper()
+
+	fixtures := t.TempDir()
+	fp := filepath.Join(fixtures, "2022", "01", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fp, []byte(fixtureInput), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := aoctest.NewServer(fixtures, aoctest.Session)
+	t.Cleanup(srv.Close)
+
+	c := &Client{
+		BaseURL:     srv.URL,
+		HTTPClient:  srv.Client(),
+		Session:     session,
+		Dir:         t.TempDir(),
+		MinInterval: -1,
+		Backoff:     time.Millisecond,
+	}
+
+	return c, srv
+}
+
+func TestClient_Get(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	for i := 0; i < 2; i++ {
+		r, err := c.Get(Day(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != fixtureInput {
+			t.Errorf("got %q, want %q", b, fixtureInput)
+		}
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+
+	if !existsFile(filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")) {
+		t.Errorf("expected input to be cached")
+	}
+}
+
+func TestClient_Get_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		day     int
+	}{
+		{
+			name:    "invalid session",
+			session: "invalid",
+			day:     1,
+		},
+		{
+			name:    "expired session",
+			session: strings.Repeat("0", 96),
+			day:     1,
+		},
+		{
+			name:    "missing input",
+			session: aoctest.Session,
+			day:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestClient(t, tt.session)
+
+			if _, err := c.Get(Day(tt.day)); err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			if existsFile(filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")) {
+				t.Errorf("expected nothing to be cached")
+			}
+		})
+	}
+}
+
+func TestClient_Get_cookieFile(t *testing.T) {
+	isolateSession(t)
+
+	c, _ := newTestClient(t, "")
+
+	if err := os.WriteFile(filepath.Join(c.Dir, "cookie.txt"), []byte(aoctest.Session+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_Get_year(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	fp := filepath.Join(srv.Dir(), "2021", "01", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fp, []byte("199\n200\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.Get(Puzzle{Year: 2021, Day: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "199\n200\n" {
+		t.Errorf("got %q, want the 2021 input", b)
+	}
+
+	if !existsFile(filepath.Join(c.Dir, "inputs", "2021", "01", "input.txt")) {
+		t.Errorf("expected input to be cached")
+	}
+
+	if _, err = c.Get(Puzzle{Year: 2014, Day: 1}); err == nil {
+		t.Errorf("expected an error for an invalid year")
+	}
+}
+
+func TestClient_Get_legacy(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	legacy := filepath.Join(c.Dir, "days", "01", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("legacy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.Get(Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "legacy\n" {
+		t.Errorf("got %q, want the legacy input", b)
+	}
+
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+
+	migrated, err := c.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(migrated) != 1 || migrated[0] != Day(1) {
+		t.Errorf("expected only day 1 to be migrated, got %v", migrated)
+	}
+
+	if !existsFile(filepath.Join(c.Dir, "inputs", "2022", "01", "input.txt")) {
+		t.Errorf("expected input to be migrated")
+	}
+
+	if !existsFile(legacy) {
+		t.Errorf("expected legacy input to be kept")
+	}
+
+	entries, err := c.Cache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the kept legacy input is listed after the migrated one
+	if len(entries) != 2 || entries[0].Legacy || entries[0].Verify() != nil || !entries[1].Legacy {
+		t.Errorf("expected the migrated input to have valid metadata, got %+v", entries)
+	}
+}
@@ -0,0 +1,198 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMinInterval is the default minimum time between two requests to the website.
+	DefaultMinInterval = 5 * time.Second
+	// DefaultTimeout is the default time limit of a single request.
+	DefaultTimeout = 30 * time.Second
+	// DefaultRetries is the default number of retries of a request that failed on a server error.
+	DefaultRetries = 3
+	// DefaultBackoff is the default time to wait before the first retry, it doubles on every retry.
+	DefaultBackoff = time.Second
+
+	// ContactEnv is the environment variable that holds the contact information for the User-Agent.
+	ContactEnv = "AOC_CONTACT"
+
+	userAgent = "github.com/pimvanhespen/aoc2022"
+
+	// throttlePath holds the time of the last request, to throttle requests across runs.
+	throttlePath = ".aoc/last-request"
+)
+
+// do sends the request with the session cookie and the User-Agent. Requests are throttled to one per
+// MinInterval and limited to Timeout. GET requests are retried with backoff on network and server errors,
+// other requests are never retried as they may have side effects. The caller must close the response body.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	cookie, err := c.cookie()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Cookie", fmt.Sprintf("session=%s", cookie))
+	req.Header.Set("User-Agent", c.userAgent())
+
+	var retries int
+	if req.Method == http.MethodGet {
+		retries = c.retries()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if !failed || attempt == retries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err = sleep(req.Context(), c.backoff()<<attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send sends a single request, after waiting for the throttle.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if err := c.throttle(req.Context()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if timeout := c.timeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	resp, err := c.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody releases the timeout of a request once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// throttle waits until MinInterval has passed since the last request, which is stored in Dir
+// so the interval is also kept between runs.
+func (c *Client) throttle(ctx context.Context) error {
+	interval := c.minInterval()
+	if interval < 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dir, err := c.dir()
+	if err != nil {
+		return err
+	}
+
+	fp := filepath.Join(dir, throttlePath)
+
+	if b, err := os.ReadFile(fp); err == nil {
+		last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(b)))
+		if err == nil {
+			wait := time.Until(last.Add(interval))
+			if wait > interval {
+				// the clock was turned back, do not wait longer than the interval
+				wait = interval
+			}
+			if err = sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+	}
+
+	return replaceFile(fp, []byte(time.Now().UTC().Format(time.RFC3339Nano)))
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (c *Client) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+
+	contact := c.Contact
+	if contact == "" {
+		contact = os.Getenv(ContactEnv)
+	}
+	if contact == "" {
+		return userAgent
+	}
+
+	return fmt.Sprintf("%s by %s", userAgent, contact)
+}
+
+func (c *Client) minInterval() time.Duration {
+	if c.MinInterval == 0 {
+		return DefaultMinInterval
+	}
+	return c.MinInterval
+}
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+func (c *Client) retries() int {
+	switch {
+	case c.Retries == 0:
+		return DefaultRetries
+	case c.Retries < 0:
+		return 0
+	}
+	return c.Retries
+}
+
+func (c *Client) backoff() time.Duration {
+	if c.Backoff <= 0 {
+		return DefaultBackoff
+	}
+	return c.Backoff
+}
@@ -0,0 +1,124 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_userAgent(t *testing.T) {
+	t.Setenv(ContactEnv, "")
+
+	c, srv := newTestClient(t, aoctest.Session)
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Contact = "me@example.com"
+	if _, err := c.RefreshPage(Day(1)); err == nil {
+		t.Fatal("expected an error, the page is not a fixture")
+	}
+
+	c.UserAgent = "custom"
+	if _, err := c.Submit(Day(1), 1, "1"); err == nil {
+		t.Fatal("expected an error, the answers are not a fixture")
+	}
+
+	want := []string{
+		userAgent,
+		userAgent + " by me@example.com",
+		"custom",
+	}
+
+	got := srv.UserAgents()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got User-Agents %q, want %q", got, want)
+	}
+}
+
+func TestClient_retry(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+	c.Retries = 2
+
+	srv.FailNext(2, http.StatusBadGateway)
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatalf("expected the download to succeed on the last retry: %v", err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+
+	srv.FailNext(3, http.StatusServiceUnavailable)
+
+	if _, err := c.RefreshPage(Day(1)); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("got error %v, want status code 503", err)
+	}
+	if n := len(srv.Requests()); n != 6 {
+		t.Errorf("got %d requests, want 6", n)
+	}
+
+	srv.FailNext(1, http.StatusInternalServerError)
+
+	if _, err := c.Submit(Day(1), 1, "1"); err == nil {
+		t.Error("expected an error")
+	}
+	if n := len(srv.Requests()); n != 7 {
+		t.Errorf("got %d requests, want 7: answers must not be retried", n)
+	}
+}
+
+func TestClient_throttle(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	c, srv := newTestClient(t, aoctest.Session)
+	c.MinInterval = interval
+
+	start := time.Now()
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Cached(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RefreshPage(Day(1)); err == nil {
+		t.Fatal("expected an error, the page is not a fixture")
+	}
+	if elapsed := time.Since(start); elapsed < interval {
+		t.Errorf("second request after %v, want at least %v", elapsed, interval)
+	}
+
+	// a new client, e.g. in a next run, keeps the interval
+	next := &Client{
+		BaseURL:     c.BaseURL,
+		HTTPClient:  c.HTTPClient,
+		Session:     c.Session,
+		Dir:         c.Dir,
+		MinInterval: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
+	defer cancel()
+
+	if _, err := next.Open(ctx, Day(2)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v while throttled", err, context.DeadlineExceeded)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestClient_timeout(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+	c.Timeout = time.Nanosecond
+	c.Retries = -1
+
+	if _, err := c.Get(Day(1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,227 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// LeaderboardInterval is the minimum time between two downloads of a private leaderboard,
+	// as asked by the website.
+	LeaderboardInterval = 15 * time.Minute
+
+	leaderboardURLFormat  = "%s/%d/leaderboard/private/view/%s.json"
+	leaderboardPathFormat = ".aoc/leaderboard/%d/%s.json"
+)
+
+var (
+	// ErrNoLeaderboard is returned when the leaderboard does not exist or the session is not a member of it.
+	ErrNoLeaderboard = errors.New("leaderboard is not accessible")
+	// ErrInvalidLeaderboard is returned for leaderboard ids that are not a positive number.
+	ErrInvalidLeaderboard = errors.New("invalid leaderboard id")
+)
+
+// Leaderboard is a private leaderboard of an event.
+type Leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID int               `json:"owner_id"`
+	Members map[string]Member `json:"members"`
+}
+
+// Member is a member of a private leaderboard.
+type Member struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStar    Unix   `json:"last_star_ts"`
+	// Completion holds the stars by day and part, both formatted as decimal numbers.
+	Completion map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Star is a star earned by a member.
+type Star struct {
+	Time  Unix `json:"get_star_ts"`
+	Index int  `json:"star_index"`
+}
+
+// Unix is a time that is encoded as the number of seconds since the Unix epoch. It also
+// decodes the number from a string, as older leaderboards do, zero is the zero time.
+type Unix struct {
+	time.Time
+}
+
+func (u *Unix) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if s, err := strconv.Unquote(string(b)); err == nil {
+		b = []byte(s)
+	}
+
+	secs, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s", b)
+	}
+
+	u.Time = time.Time{}
+	if secs > 0 {
+		u.Time = time.Unix(secs, 0).UTC()
+	}
+	return nil
+}
+
+func (u Unix) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return []byte("0"), nil
+	}
+	return []byte(strconv.FormatInt(u.Unix(), 10)), nil
+}
+
+// Leaderboard returns the private leaderboard with the given id. A downloaded leaderboard is cached
+// in Dir and reused for LeaderboardInterval, so it can be requested as often as needed.
+func (c *Client) Leaderboard(ctx context.Context, year int, id string) (*Leaderboard, error) {
+	// the id is part of the URL and the path of the cached leaderboard
+	if n, err := strconv.Atoi(id); err != nil || n <= 0 || strconv.Itoa(n) != id {
+		return nil, fmt.Errorf("%w %q: want a positive number", ErrInvalidLeaderboard, id)
+	}
+
+	dir, err := c.dir()
+	if err != nil {
+		return nil, err
+	}
+
+	fp := filepath.Join(dir, fmt.Sprintf(leaderboardPathFormat, year, id))
+
+	if fi, err := os.Stat(fp); err == nil && time.Since(fi.ModTime()) < LeaderboardInterval {
+		f, err := os.Open(fp)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		return ParseLeaderboard(f)
+	}
+
+	b, err := c.downloadLeaderboard(ctx, year, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := ParseLeaderboard(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return lb, replaceFile(fp, b)
+}
+
+func (c *Client) downloadLeaderboard(ctx context.Context, year int, id string) (_ []byte, err error) {
+	remote := fmt.Sprintf(leaderboardURLFormat, c.baseURL(), year, id)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", remote, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if err = responseError(resp); err != nil {
+		return nil, err
+	}
+
+	// the website redirects to the overview of private leaderboards instead of returning an error
+	if resp.Request.URL.Path != req.URL.Path {
+		return nil, fmt.Errorf("%d leaderboard %s: %w", year, id, ErrNoLeaderboard)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// ParseLeaderboard parses the JSON of a private leaderboard.
+func ParseLeaderboard(r io.Reader) (*Leaderboard, error) {
+	var lb Leaderboard
+	if err := json.NewDecoder(r).Decode(&lb); err != nil {
+		return nil, fmt.Errorf("invalid leaderboard: %w", err)
+	}
+	return &lb, nil
+}
+
+// Year returns the year of the event.
+func (lb *Leaderboard) Year() int {
+	year, _ := strconv.Atoi(lb.Event)
+	return year
+}
+
+// Ranking returns the members ordered by local score, then by stars and the time of their last star.
+func (lb *Leaderboard) Ranking() []Member {
+	members := make([]Member, 0, len(lb.Members))
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		switch {
+		case a.LocalScore != b.LocalScore:
+			return a.LocalScore > b.LocalScore
+		case a.Stars != b.Stars:
+			return a.Stars > b.Stars
+		case !a.LastStar.Equal(b.LastStar.Time):
+			return a.LastStar.Before(b.LastStar.Time)
+		}
+		return a.ID < b.ID
+	})
+
+	return members
+}
+
+// Member returns the member with the given name, or with the given id when it is a number.
+func (lb *Leaderboard) Member(name string) (Member, bool) {
+	for _, m := range lb.Members {
+		if m.Name == name || strconv.Itoa(m.ID) == name {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// DisplayName returns the name of the member as shown on the website.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// Star returns the time the member earned the star for the given part of the day.
+func (m Member) Star(day, part int) (time.Time, bool) {
+	star, ok := m.Completion[strconv.Itoa(day)][strconv.Itoa(part)]
+	if !ok || star.Time.IsZero() {
+		return time.Time{}, false
+	}
+	return star.Time.Time, true
+}
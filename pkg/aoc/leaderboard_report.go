@@ -0,0 +1,236 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Table is a report with a header, it is written as aligned text or as a Markdown table.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// WriteText writes the table with aligned columns.
+func (t Table) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for _, row := range append([][]string{t.Header}, t.Rows...) {
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the table in the Markdown table syntax.
+func (t Table) WriteMarkdown(w io.Writer) error {
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(line(t.Header))
+	sb.WriteString(strings.Repeat("| --- ", len(t.Header)) + "|\n")
+	for _, row := range t.Rows {
+		sb.WriteString(line(row))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Completion holds the time a member needed for each part of a day, counted from the release of the puzzle.
+// A part that is not solved has a zero duration.
+type Completion struct {
+	Member Member
+	Part1  time.Duration
+	Part2  time.Duration
+}
+
+// Delta returns the time between the first and the second star, or zero when part 2 is not solved.
+func (c Completion) Delta() time.Duration {
+	if c.Part2 == 0 {
+		return 0
+	}
+	return c.Part2 - c.Part1
+}
+
+// Completions returns the completion times of the members that solved at least one part of the day,
+// fastest first.
+func (lb *Leaderboard) Completions(day int) []Completion {
+	unlock := Puzzle{Year: lb.Year(), Day: day}.Unlock()
+
+	var completions []Completion
+
+	for _, m := range lb.Members {
+		c := Completion{Member: m}
+		if t, ok := m.Star(day, 1); ok {
+			c.Part1 = t.Sub(unlock)
+		}
+		if t, ok := m.Star(day, 2); ok {
+			c.Part2 = t.Sub(unlock)
+		}
+		if c.Part1 != 0 {
+			completions = append(completions, c)
+		}
+	}
+
+	sort.Slice(completions, func(i, j int) bool {
+		a, b := completions[i], completions[j]
+		switch {
+		case (a.Part2 == 0) != (b.Part2 == 0):
+			return a.Part2 != 0
+		case a.Part2 != b.Part2:
+			return a.Part2 < b.Part2
+		case a.Part1 != b.Part1:
+			return a.Part1 < b.Part1
+		}
+		return a.Member.ID < b.Member.ID
+	})
+
+	return completions
+}
+
+// StarDelta is the difference between the times two members earned a star, it is positive
+// when the second member was slower.
+type StarDelta struct {
+	Day   int
+	Part  int
+	Delta time.Duration
+}
+
+// Deltas returns the difference between the star times of a and b, for every star both members earned.
+func (lb *Leaderboard) Deltas(a, b Member) []StarDelta {
+	var deltas []StarDelta
+
+	for day := 1; day <= 25; day++ {
+		for part := 1; part <= 2; part++ {
+			ta, okA := a.Star(day, part)
+			tb, okB := b.Star(day, part)
+			if okA && okB {
+				deltas = append(deltas, StarDelta{Day: day, Part: part, Delta: tb.Sub(ta)})
+			}
+		}
+	}
+
+	return deltas
+}
+
+// StandingsTable returns the ranking of the members with a star chart of the days, like the website:
+// * is one star, ** are both stars and . is no star.
+func (lb *Leaderboard) StandingsTable() Table {
+	last := lastDay(lb)
+
+	t := Table{Header: []string{"#", "Member", "Score", "Stars", "Days"}}
+
+	for i, m := range lb.Ranking() {
+		var days strings.Builder
+		for day := 1; day <= last; day++ {
+			if day > 1 {
+				days.WriteByte(' ')
+			}
+			_, one := m.Star(day, 1)
+			_, two := m.Star(day, 2)
+			switch {
+			case two:
+				days.WriteString("**")
+			case one:
+				days.WriteString("* ")
+			default:
+				days.WriteString(". ")
+			}
+		}
+
+		t.Rows = append(t.Rows, []string{
+			strconv.Itoa(i + 1),
+			m.DisplayName(),
+			strconv.Itoa(m.LocalScore),
+			strconv.Itoa(m.Stars),
+			strings.TrimRight(days.String(), " "),
+		})
+	}
+
+	return t
+}
+
+// DayTable returns the completion times of the day.
+func (lb *Leaderboard) DayTable(day int) Table {
+	t := Table{Header: []string{"#", "Member", "Part 1", "Part 2", "Delta"}}
+
+	for i, c := range lb.Completions(day) {
+		t.Rows = append(t.Rows, []string{
+			strconv.Itoa(i + 1),
+			c.Member.DisplayName(),
+			formatDuration(c.Part1),
+			formatDuration(c.Part2),
+			formatDuration(c.Delta()),
+		})
+	}
+
+	return t
+}
+
+// DeltaTable returns the star times of a and b relative to the release, with the difference between them.
+func (lb *Leaderboard) DeltaTable(a, b Member) Table {
+	t := Table{Header: []string{"Day", "Part", a.DisplayName(), b.DisplayName(), "Delta"}}
+
+	for _, d := range lb.Deltas(a, b) {
+		unlock := Puzzle{Year: lb.Year(), Day: d.Day}.Unlock()
+		ta, _ := a.Star(d.Day, d.Part)
+		tb, _ := b.Star(d.Day, d.Part)
+
+		delta := formatDuration(d.Delta)
+		switch {
+		case d.Delta > 0:
+			delta = "+" + formatDuration(d.Delta)
+		case d.Delta < 0:
+			delta = "-" + formatDuration(d.Delta)
+		}
+
+		t.Rows = append(t.Rows, []string{
+			strconv.Itoa(d.Day),
+			strconv.Itoa(d.Part),
+			formatDuration(ta.Sub(unlock)),
+			formatDuration(tb.Sub(unlock)),
+			delta,
+		})
+	}
+
+	return t
+}
+
+// lastDay returns the last day on which any member earned a star.
+func lastDay(lb *Leaderboard) int {
+	var last int
+	for _, m := range lb.Members {
+		for day := range m.Completion {
+			if n, err := strconv.Atoi(day); err == nil && n > last {
+				last = n
+			}
+		}
+	}
+	return last
+}
+
+// formatDuration formats the duration as hours, minutes and seconds, e.g. 1:02:03, without a sign.
+// Zero, a missing star or no difference, is formatted as - in all tables.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	if d < 0 {
+		d = -d
+	}
+
+	secs := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
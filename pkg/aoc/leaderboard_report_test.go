@@ -0,0 +1,135 @@
+package aoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLeaderboard_Completions(t *testing.T) {
+	lb := readLeaderboard(t)
+
+	type completion struct {
+		name         string
+		part1, part2 time.Duration
+	}
+
+	var got []completion
+	for _, c := range lb.Completions(1) {
+		got = append(got, completion{c.Member.DisplayName(), c.Part1, c.Part2})
+	}
+
+	want := []completion{
+		{"bob", 12 * time.Minute, 14 * time.Minute},
+		{"alice", 10 * time.Minute, 15*time.Minute + 30*time.Second},
+		{"(anonymous user #1003)", time.Hour, 0},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if len(lb.Completions(3)) != 0 {
+		t.Error("got completions for a day nobody solved")
+	}
+}
+
+func TestLeaderboard_Deltas(t *testing.T) {
+	lb := readLeaderboard(t)
+	alice, _ := lb.Member("alice")
+	bob, _ := lb.Member("bob")
+
+	want := []StarDelta{
+		{Day: 1, Part: 1, Delta: 2 * time.Minute},
+		{Day: 1, Part: 2, Delta: -90 * time.Second},
+		{Day: 2, Part: 1, Delta: 10 * time.Minute},
+	}
+
+	if got := lb.Deltas(alice, bob); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLeaderboard_tables(t *testing.T) {
+	lb := readLeaderboard(t)
+	alice, _ := lb.Member("alice")
+	bob, _ := lb.Member("bob")
+
+	tests := []struct {
+		name     string
+		table    Table
+		markdown bool
+		want     string
+	}{
+		{
+			name:  "standings",
+			table: lb.StandingsTable(),
+			want: `#  Member                  Score  Stars  Days
+1  alice                   11     4      ** **
+2  bob                     7      3      ** *
+3  (anonymous user #1003)  1      1      *  .
+`,
+		},
+		{
+			name:     "day",
+			table:    lb.DayTable(2),
+			markdown: true,
+			want: `| # | Member | Part 1 | Part 2 | Delta |
+| --- | --- | --- | --- | --- |
+| 1 | alice | 0:20:00 | 1:02:03 | 0:42:03 |
+| 2 | bob | 0:30:00 | - | - |
+`,
+		},
+		{
+			name:  "deltas",
+			table: lb.DeltaTable(alice, bob),
+			want: `Day  Part  alice    bob      Delta
+1    1     0:10:00  0:12:00  +0:02:00
+1    2     0:15:30  0:14:00  -0:01:30
+2    1     0:20:00  0:30:00  +0:10:00
+`,
+		},
+		{
+			// no difference is formatted like a missing star
+			name:  "equal",
+			table: lb.DeltaTable(alice, alice),
+			want: `Day  Part  alice    alice    Delta
+1    1     0:10:00  0:10:00  -
+1    2     0:15:30  0:15:30  -
+2    1     0:20:00  0:20:00  -
+2    2     1:02:03  1:02:03  -
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sb strings.Builder
+
+			write := tc.table.WriteText
+			if tc.markdown {
+				write = tc.table.WriteMarkdown
+			}
+			if err := write(&sb); err != nil {
+				t.Fatal(err)
+			}
+
+			if sb.String() != tc.want {
+				t.Errorf("got\n%s\nwant\n%s", sb.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestTable_WriteMarkdown_escape(t *testing.T) {
+	var sb strings.Builder
+	table := Table{Header: []string{"Member"}, Rows: [][]string{{"a|b"}}}
+	if err := table.WriteMarkdown(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "| Member |\n| --- |\n| a\\|b |\n"; sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+}
@@ -0,0 +1,136 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readLeaderboard(t *testing.T) *Leaderboard {
+	t.Helper()
+
+	f, err := os.Open("testdata/leaderboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lb, err := ParseLeaderboard(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return lb
+}
+
+func TestParseLeaderboard(t *testing.T) {
+	lb := readLeaderboard(t)
+
+	if lb.Year() != 2022 || lb.OwnerID != 1001 || len(lb.Members) != 3 {
+		t.Fatalf("got year %d, owner %d and %d members", lb.Year(), lb.OwnerID, len(lb.Members))
+	}
+
+	var names []string
+	for _, m := range lb.Ranking() {
+		names = append(names, m.DisplayName())
+	}
+	if want := []string{"alice", "bob", "(anonymous user #1003)"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got ranking %q, want %q", names, want)
+	}
+
+	anon, ok := lb.Member("1003")
+	if !ok {
+		t.Fatal("member 1003 not found")
+	}
+	// older leaderboards encode timestamps as strings
+	if want := time.Unix(1669874400, 0); !anon.LastStar.Equal(want) {
+		t.Errorf("got last star %v, want %v", anon.LastStar, want)
+	}
+
+	bob, _ := lb.Member("bob")
+	if got, ok := bob.Star(1, 2); !ok || !got.Equal(time.Unix(1669871640, 0)) {
+		t.Errorf("got star %v %t, want %v", got, ok, time.Unix(1669871640, 0))
+	}
+	if _, ok = bob.Star(2, 2); ok {
+		t.Error("got star for day 2 part 2, which bob did not solve")
+	}
+}
+
+func TestParseLeaderboard_invalid(t *testing.T) {
+	_, err := ParseLeaderboard(strings.NewReader("<!DOCTYPE html>"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestClient_Leaderboard(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	b, err := os.ReadFile("testdata/leaderboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp := filepath.Join(srv.Dir(), "2022", "leaderboard", "1001.json")
+	if err = os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(fp, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		lb, err := c.Leaderboard(context.Background(), 2022, "1001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lb.Members) != 3 {
+			t.Errorf("got %d members, want 3", len(lb.Members))
+		}
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("got %d requests, want 1: the leaderboard should be cached", n)
+	}
+
+	// the cached leaderboard is downloaded again once it is older than the interval
+	cached := filepath.Join(c.Dir, fmt.Sprintf(leaderboardPathFormat, 2022, "1001"))
+	old := time.Now().Add(-LeaderboardInterval - time.Minute)
+	if err = os.Chtimes(cached, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = c.Leaderboard(context.Background(), 2022, "1001"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+
+	if _, err = c.Leaderboard(context.Background(), 2022, "404"); !errors.Is(err, ErrNoLeaderboard) {
+		t.Errorf("got error %v, want %v", err, ErrNoLeaderboard)
+	}
+}
+
+func TestClient_Leaderboard_invalidID(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	for _, id := range []string{"", "0", "-1", "+1", "01", "../../x", "1/../2"} {
+		if _, err := c.Leaderboard(context.Background(), 2022, id); !errors.Is(err, ErrInvalidLeaderboard) {
+			t.Errorf("id %q: got error %v, want %v", id, err, ErrInvalidLeaderboard)
+		}
+	}
+
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+	if existsFile(filepath.Join(c.Dir, ".aoc")) {
+		t.Error("expected nothing to be cached")
+	}
+}
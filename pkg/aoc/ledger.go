@@ -0,0 +1,116 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const ledgerPath = ".aoc/ledger.json"
+
+var (
+	ErrKnownWrong    = errors.New("answer was already rejected")
+	ErrOutOfBounds   = errors.New("answer is outside the bounds of earlier attempts")
+	ErrAlreadySolved = errors.New("part was already solved with another answer")
+)
+
+// Attempt is a single submitted answer.
+type Attempt struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// Ledger records every submitted answer, so known-wrong answers are never submitted twice.
+type Ledger struct {
+	path     string
+	attempts []Attempt
+}
+
+// OpenLedger reads the ledger stored at path. A missing file results in an empty ledger.
+func OpenLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, &l.attempts); err != nil {
+		return nil, fmt.Errorf("read ledger %s: %w", path, err)
+	}
+
+	return l, nil
+}
+
+// Attempts returns the recorded attempts for the given part of the puzzle, oldest first.
+func (l *Ledger) Attempts(p Puzzle, part int) []Attempt {
+	var attempts []Attempt
+	for _, a := range l.attempts {
+		if a.Year == p.Year && a.Day == p.Day && a.Part == part {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts
+}
+
+// Record adds the attempt to the ledger, call Save to persist it.
+func (l *Ledger) Record(a Attempt) {
+	l.attempts = append(l.attempts, a)
+}
+
+// Check returns an error when the answer should not be submitted. When the part was already solved
+// with the same answer, it returns the correct attempt instead.
+func (l *Ledger) Check(p Puzzle, part int, answer string) (*Attempt, error) {
+	n, numErr := strconv.Atoi(answer)
+
+	for _, a := range l.Attempts(p, part) {
+		switch a.Verdict {
+		case Correct:
+			if a.Answer == answer {
+				return &a, nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrAlreadySolved, a.Answer)
+		case RateLimited, WrongLevel:
+			continue
+		}
+
+		if a.Answer == answer {
+			return nil, fmt.Errorf("%w: %s was %s", ErrKnownWrong, answer, a.Verdict)
+		}
+
+		if numErr != nil {
+			continue
+		}
+
+		bound, err := strconv.Atoi(a.Answer)
+		if err != nil {
+			continue
+		}
+
+		if (a.Verdict == TooHigh && n >= bound) || (a.Verdict == TooLow && n <= bound) {
+			return nil, fmt.Errorf("%w: %s was %s", ErrOutOfBounds, a.Answer, a.Verdict)
+		}
+	}
+
+	return nil, nil
+}
+
+// Save writes the ledger to disk.
+func (l *Ledger) Save() error {
+	b, err := json.MarshalIndent(l.attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(l.path, b)
+}
@@ -0,0 +1,287 @@
+package aoc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	pageURLFormat  = "%s/%d/day/%d"
+	pagePathFormat = "inputs/%d/%02d/puzzle.html"
+
+	fixtureInputFormat = "example%s.txt"
+	fixtureAnswersFile = "answers.txt"
+)
+
+var (
+	descRegex   = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	preRegex    = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerRegex = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+)
+
+// Page is the description of a puzzle, as shown on its page on the website.
+type Page struct {
+	Puzzle Puzzle
+	Parts  []Part
+}
+
+// Part is the description of a single part of a puzzle.
+type Part struct {
+	// Examples are the contents of the code blocks, in order of appearance.
+	Examples []string
+	// Answer is the last emphasised code in the description, which usually is the answer to the example.
+	Answer string
+}
+
+// Fixture is an example input with the expected answer for one part of the puzzle.
+type Fixture struct {
+	Part   int
+	Input  string
+	Answer string
+}
+
+// Page returns the description of the given puzzle. It is downloaded when it is not cached yet,
+// so it works offline once cached. The second part is only shown on the website after the first
+// part is solved, use RefreshPage to replace a cached page that misses it.
+func (c *Client) Page(p Puzzle) (*Page, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	fp, err := c.pagePath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existsFile(fp) {
+		return c.RefreshPage(p)
+	}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return ParsePage(p, f)
+}
+
+// RefreshPage downloads the description of the given puzzle and replaces the cached page.
+func (c *Client) RefreshPage(p Puzzle) (_ *Page, err error) {
+	if err = p.Validate(); err != nil {
+		return nil, err
+	}
+
+	fp, err := c.pagePath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := fmt.Sprintf(pageURLFormat, c.baseURL(), p.Year, p.Day)
+
+	req, err := http.NewRequest("GET", remote, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if err = responseError(resp); err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkLogin(b); err != nil {
+		return nil, err
+	}
+
+	page, err := ParsePage(p, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return page, replaceFile(fp, b)
+}
+
+// ParsePage parses the HTML of a puzzle page.
+func ParsePage(p Puzzle, r io.Reader) (*Page, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := descRegex.FindAllStringSubmatch(string(b), -1)
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("no puzzle description found on the page of %s", p)
+	}
+
+	page := &Page{Puzzle: p}
+
+	for _, article := range articles {
+		var part Part
+
+		for _, m := range preRegex.FindAllStringSubmatch(article[1], -1) {
+			part.Examples = append(part.Examples, htmlText(m[1]))
+		}
+
+		if answers := answerRegex.FindAllStringSubmatch(article[1], -1); len(answers) > 0 {
+			last := answers[len(answers)-1]
+			part.Answer = htmlText(last[1] + last[2])
+		}
+
+		page.Parts = append(page.Parts, part)
+	}
+
+	return page, nil
+}
+
+// Fixtures returns the example of each part with its expected answer. The first example of
+// a part is used as its input, parts without examples reuse the input of the previous part.
+func (pg *Page) Fixtures() []Fixture {
+	var fixtures []Fixture
+	var input string
+
+	for i, part := range pg.Parts {
+		if len(part.Examples) > 0 {
+			input = part.Examples[0]
+		}
+
+		if input == "" || part.Answer == "" {
+			continue
+		}
+
+		fixtures = append(fixtures, Fixture{
+			Part:   i + 1,
+			Input:  input,
+			Answer: part.Answer,
+		})
+	}
+
+	return fixtures
+}
+
+// WriteFixtures writes the fixtures to dir, usually the testdata directory of a day.
+// The input of the first fixture is written to example.txt when it is part 1, the input of other parts
+// is only written when it differs from the previous fixture, e.g. to example2.txt. The answers are written
+// to answers.txt, one line per fixture with the part and the answer, such as "2 45000".
+func WriteFixtures(dir string, fixtures []Fixture) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var answers strings.Builder
+	var prev string
+
+	for _, f := range fixtures {
+		if f.Input != prev {
+			if err := os.WriteFile(filepath.Join(dir, fixtureInputName(f.Part)), []byte(f.Input), 0644); err != nil {
+				return err
+			}
+			prev = f.Input
+		}
+		_, _ = fmt.Fprintf(&answers, "%d %s\n", f.Part, f.Answer)
+	}
+
+	return os.WriteFile(filepath.Join(dir, fixtureAnswersFile), []byte(answers.String()), 0644)
+}
+
+// ReadFixtures reads the fixtures that were written to dir by WriteFixtures. The input of a part
+// is the example of the part, or else the example of the closest part before it.
+func ReadFixtures(dir string) ([]Fixture, error) {
+	f, err := os.Open(filepath.Join(dir, fixtureAnswersFile))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var fixtures []Fixture
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		num, answer, _ := strings.Cut(line, " ")
+		part, err := strconv.Atoi(num)
+		if err != nil || part < 1 {
+			return nil, fmt.Errorf("%s: invalid line %q, want the part and the answer", fixtureAnswersFile, line)
+		}
+
+		input, err := readFixtureInput(dir, part)
+		if err != nil {
+			return nil, err
+		}
+
+		fixtures = append(fixtures, Fixture{
+			Part:   part,
+			Input:  input,
+			Answer: answer,
+		})
+	}
+
+	return fixtures, scanner.Err()
+}
+
+// readFixtureInput reads the example of the part, or of the closest part before it.
+func readFixtureInput(dir string, part int) (string, error) {
+	for p := part; p >= 1; p-- {
+		b, err := os.ReadFile(filepath.Join(dir, fixtureInputName(p)))
+		switch {
+		case err == nil:
+			return string(b), nil
+		case !errors.Is(err, os.ErrNotExist):
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no example for part %d in %s: %w", part, dir, os.ErrNotExist)
+}
+
+func fixtureInputName(part int) string {
+	if part == 1 {
+		return fmt.Sprintf(fixtureInputFormat, "")
+	}
+	return fmt.Sprintf(fixtureInputFormat, fmt.Sprint(part))
+}
+
+func (c *Client) pagePath(p Puzzle) (string, error) {
+	dir, err := c.dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf(pagePathFormat, p.Year, p.Day)), nil
+}
+
+// htmlText strips the tags from the HTML fragment and unescapes the entities.
+func htmlText(s string) string {
+	return html.UnescapeString(tagRegex.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,108 @@
+package aoc
+
+import (
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const examplePage = "1000\n2000\n3000\n\n4000\n\n5000\n6000\n\n7000\n8000\n9000\n\n10000\n"
+
+func TestParsePage(t *testing.T) {
+	f, err := os.Open("testdata/puzzle.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	page, err := ParsePage(Day(1), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Fixture{
+		{Part: 1, Input: examplePage, Answer: "24000"},
+		{Part: 2, Input: examplePage, Answer: "45000"},
+	}
+
+	if got := page.Fixtures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Page(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	b, err := os.ReadFile("testdata/puzzle.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(srv.Dir(), "2022", "01", "puzzle.html"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		page, err := c.Page(Day(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Parts) != 2 {
+			t.Fatalf("expected 2 parts, got %d", len(page.Parts))
+		}
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestWriteFixtures(t *testing.T) {
+	dir := t.TempDir()
+
+	want := []Fixture{
+		{Part: 1, Input: "a\n", Answer: "1"},
+		{Part: 2, Input: "a\n", Answer: "2"},
+		{Part: 3, Input: "b\n", Answer: "3"},
+	}
+
+	if err := WriteFixtures(dir, want); err != nil {
+		t.Fatal(err)
+	}
+
+	if existsFile(filepath.Join(dir, "example2.txt")) {
+		t.Errorf("expected a repeated input not to be written")
+	}
+
+	got, err := ReadFixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteFixtures_skippedPart(t *testing.T) {
+	dir := t.TempDir()
+
+	// part 1 has no answer on the page
+	want := []Fixture{
+		{Part: 2, Input: "b\n", Answer: "2"},
+	}
+
+	if err := WriteFixtures(dir, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadFixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError is an error at a position in the puzzle input. It is returned by the parser helpers,
+// which fill in the line and its text. Parse functions can return one made by ErrorAt to point
+// at the offending column. Run fills in the day.
+type ParseError struct {
+	// Day is the day of the puzzle, it is 0 when unknown.
+	Day int
+	// Line is the line number, counting from 1.
+	Line int
+	// Column is the byte offset in the line, counting from 1. It is 0 when unknown.
+	Column int
+	// Text is the text of the offending line.
+	Text string
+	Err  error
+}
+
+// ErrorAt returns a ParseError for the given column of the line that is being parsed.
+func ErrorAt(column int, err error) *ParseError {
+	return &ParseError{Column: column, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+
+	if e.Day != 0 {
+		_, _ = fmt.Fprintf(&b, "day %d: ", e.Day)
+	}
+
+	_, _ = fmt.Fprintf(&b, "line %d", e.Line)
+	if e.Column != 0 {
+		_, _ = fmt.Fprintf(&b, ", column %d", e.Column)
+	}
+
+	_, _ = fmt.Fprintf(&b, ": %v", e.Err)
+
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Excerpt renders the offending line with a caret below the column, e.g.
+//
+//	3 | move x from 1 to 2
+//	  |      ^
+//
+// The whole line is marked when the column is unknown.
+func (e *ParseError) Excerpt() string {
+	prefix := fmt.Sprint(e.Line)
+	margin := strings.Repeat(" ", len(prefix))
+
+	marker := "^"
+	switch {
+	case e.Column > 0:
+		marker = strings.Repeat(" ", e.Column-1) + marker
+	case e.Text != "":
+		marker = strings.Repeat(marker, len(e.Text))
+	}
+
+	return fmt.Sprintf("%s | %s\n%s | %s\n", prefix, e.Text, margin, marker)
+}
+
+// lineError returns err as a ParseError on the given line. The position of a ParseError that
+// is returned by a parse function is relative to the line that was passed to it.
+func lineError(err error, line int, text string) *ParseError {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return &ParseError{Line: line, Text: text, Err: err}
+	}
+
+	pe.Line += line
+	if pe.Text == "" {
+		pe.Text = text
+	}
+
+	return pe
+}
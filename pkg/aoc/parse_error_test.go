@@ -0,0 +1,106 @@
+package aoc
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	errBad := errors.New("bad digit")
+
+	parse := func(line string) (int, error) {
+		if i := strings.IndexByte(line, 'x'); i >= 0 {
+			return 0, ErrorAt(i+1, errBad)
+		}
+		return strconv.Atoi(line)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		message string
+		excerpt string
+	}{
+		{
+			name:    "column",
+			input:   "1\n2\n3x4\n",
+			message: "line 3, column 2: bad digit",
+			excerpt: "3 | 3x4\n  |  ^\n",
+		},
+		{
+			name:    "no column",
+			input:   "1\n\n12a\n",
+			message: `line 3: strconv.Atoi: parsing "12a": invalid syntax`,
+			excerpt: "3 | 12a\n  | ^^^\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parser[int]{SkipEmptyLines: true, ParseFn: parse}.Rows(strings.NewReader(tc.input))
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("got error %v, want a *ParseError", err)
+			}
+
+			if pe.Error() != tc.message {
+				t.Errorf("got message %q, want %q", pe.Error(), tc.message)
+			}
+			if pe.Excerpt() != tc.excerpt {
+				t.Errorf("got excerpt\n%s\nwant\n%s", pe.Excerpt(), tc.excerpt)
+			}
+		})
+	}
+}
+
+func TestParseError_blocks(t *testing.T) {
+	input := "1\n2\n\n3\n4x\n"
+
+	_, err := Parser[int]{ParseFn: strconv.Atoi}.Blocks(strings.NewReader(input))
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("got error %v, want a *ParseError", err)
+	}
+	if pe.Line != 5 || pe.Text != "4x" {
+		t.Errorf("got line %d %q, want line 5 %q", pe.Line, pe.Text, "4x")
+	}
+
+	_, err = ParseBlocks(strings.NewReader(input), func(b Block) (int, error) {
+		return 0, b.ErrorAt(1, 2, errors.New("unexpected x"))
+	})
+	if !errors.As(err, &pe) {
+		t.Fatalf("got error %v, want a *ParseError", err)
+	}
+	if pe.Line != 2 || pe.Column != 2 || pe.Text != "2" {
+		t.Errorf("got line %d column %d %q, want line 2 column 2 %q", pe.Line, pe.Column, pe.Text, "2")
+	}
+
+	err = ParseSections(strings.NewReader("header\n"), func(Block) error { return nil }, func(Block) error { return nil })
+	if !errors.As(err, &pe) {
+		t.Fatalf("got error %v, want a *ParseError", err)
+	}
+	if pe.Line != 2 {
+		t.Errorf("got line %d, want the end of the input at line 2", pe.Line)
+	}
+}
+
+func TestRun_parseError(t *testing.T) {
+	s := NewSolution[[]int, int, int](func(r io.Reader) ([]int, error) {
+		return ParseRows(r, strconv.Atoi)
+	}, nil, Infallible(func([]int) int { return 0 }))
+
+	r := Run(7, s, 2, []byte("1\nx\n"))
+
+	var pe *ParseError
+	if !errors.As(r.Err, &pe) {
+		t.Fatalf("got error %v, want a *ParseError", r.Err)
+	}
+	if want := `day 7: line 2: strconv.Atoi: parsing "x": invalid syntax`; pe.Error() != want {
+		t.Errorf("got %q, want %q", pe.Error(), want)
+	}
+}
@@ -1,11 +1,13 @@
 package aoc
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// ParseFn parses a single line. It may return an error made by ErrorAt to point at the offending column.
 type ParseFn[Row any] func(string) (Row, error)
 
 type Parser[Row any] struct {
@@ -13,40 +15,233 @@ type Parser[Row any] struct {
 	ParseFn        ParseFn[Row]
 }
 
+// Rows parses every line of the input, errors are returned as a *ParseError.
 func (p Parser[Row]) Rows(reader io.Reader) ([]Row, error) {
 	var rows []Row
 
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" && p.SkipEmptyLines {
+	it := p.Iter(context.Background(), reader)
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+
+	return rows, it.Err()
+}
+
+// Iter returns an iterator that parses the rows of the input one at a time, so large inputs are
+// parsed in constant memory. Iteration stops when the context is done.
+func (p Parser[Row]) Iter(ctx context.Context, reader io.Reader) *RowIterator[Row] {
+	return &RowIterator[Row]{
+		parser: p,
+		lines:  NewLines(ctx, reader),
+	}
+}
+
+// RowIterator iterates over the parsed rows of an input, see Parser.Iter.
+type RowIterator[Row any] struct {
+	parser Parser[Row]
+	lines  *Lines
+	row    Row
+	err    error
+}
+
+// Next parses the next row, it returns false at the end of the input or on an error.
+func (it *RowIterator[Row]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.lines.Next() {
+		line := it.lines.Text()
+		if line == "" && it.parser.SkipEmptyLines {
 			continue
 		}
-		row, err := p.ParseFn(line)
+
+		row, err := it.parser.ParseFn(line)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse line '%q': %w", line, err)
+			it.err = lineError(err, it.lines.Line(), line)
+			return false
 		}
-		rows = append(rows, row)
+
+		it.row = row
+		return true
 	}
 
-	return rows, nil
+	it.err = it.lines.Err()
+	return false
+}
+
+// Row returns the current row.
+func (it *RowIterator[Row]) Row() Row {
+	return it.row
+}
+
+// Err returns the error that stopped the iteration, parse errors are returned as a *ParseError.
+// It is nil at the end of the input.
+func (it *RowIterator[Row]) Err() error {
+	return it.err
+}
+
+// Close closes the underlying reader when it is an io.Closer.
+func (it *RowIterator[Row]) Close() error {
+	return it.lines.Close()
 }
 
+// ParseRows parses every line of the input, errors are returned as a *ParseError.
+// Blank lines are not parsed, they result in the zero Row.
 func ParseRows[Row any](reader io.Reader, fn ParseFn[Row]) ([]Row, error) {
 	var rows []Row
 
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
+	scanner := newScanner(reader)
+	for n := 1; scanner.Scan(); n++ {
 		line := scanner.Text()
 		if line == "" {
 			rows = append(rows, *new(Row))
+			continue
 		}
 		row, err := fn(line)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse line '%q': %w", line, err)
+			return nil, lineError(err, n, line)
 		}
 		rows = append(rows, row)
 	}
 
-	return rows, nil
+	return rows, scanner.Err()
+}
+
+// Block is a group of consecutive lines of the input. Blocks are separated by blank lines.
+type Block struct {
+	// Line is the line number of the first line of the block, counting from 1.
+	Line  int
+	Lines []string
+}
+
+// ErrorAt returns a ParseError for the given column of the i-th line of the block.
+func (b Block) ErrorAt(i, column int, err error) *ParseError {
+	return &ParseError{Line: i, Column: column, Text: b.Lines[i], Err: err}
+}
+
+// AtLine returns err as a ParseError on the i-th line of the block, e.g. the error of a ParseFn
+// that parsed the line. The column of a ParseError is kept.
+func (b Block) AtLine(i int, err error) *ParseError {
+	return lineError(err, i, b.Lines[i])
+}
+
+// ParseBlockFn parses a block. Errors without a position are reported at the first line of the block,
+// use Block.ErrorAt to point at another line.
+type ParseBlockFn[T any] func(Block) (T, error)
+
+// ReadBlocks splits the input into blocks. Lines that only contain whitespace separate the blocks,
+// consecutive blank lines do not result in empty blocks.
+func ReadBlocks(reader io.Reader) ([]Block, error) {
+	var blocks []Block
+	var block Block
+
+	scanner := newScanner(reader)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(block.Lines) > 0 {
+				blocks = append(blocks, block)
+			}
+			block = Block{}
+			continue
+		}
+
+		if len(block.Lines) == 0 {
+			block.Line = n
+		}
+		block.Lines = append(block.Lines, line)
+	}
+
+	if len(block.Lines) > 0 {
+		blocks = append(blocks, block)
+	}
+
+	return blocks, scanner.Err()
+}
+
+// ParseBlocks parses every block of the input with fn, errors are returned as a *ParseError.
+func ParseBlocks[T any](reader io.Reader, fn ParseBlockFn[T]) ([]T, error) {
+	blocks, err := ReadBlocks(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(blocks))
+	for _, block := range blocks {
+		v, err := fn(block)
+		if err != nil {
+			return nil, lineError(err, block.Line, block.Lines[0])
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// Blocks parses every line of the input with ParseFn and groups the rows by block.
+func (p Parser[Row]) Blocks(reader io.Reader) ([][]Row, error) {
+	return ParseBlocks(reader, func(b Block) ([]Row, error) {
+		rows := make([]Row, 0, len(b.Lines))
+		for i, line := range b.Lines {
+			row, err := p.ParseFn(line)
+			if err != nil {
+				return nil, lineError(err, i, line)
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	})
+}
+
+// Section returns a function that parses a block with fn and stores the result in dst.
+// It is meant to be used with ParseSections.
+func Section[T any](dst *T, fn ParseBlockFn[T]) func(Block) error {
+	return func(b Block) error {
+		v, err := fn(b)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}
+}
+
+// ParseSections parses inputs that consist of a fixed number of different blocks, e.g. a header followed
+// by a list of instructions. Every block is parsed by the section at the same position.
+// Errors, including a missing or an extra section, are returned as a *ParseError.
+func ParseSections(reader io.Reader, sections ...func(Block) error) error {
+	blocks, err := ReadBlocks(reader)
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) > len(sections) {
+		extra := blocks[len(sections)]
+		return &ParseError{
+			Line: extra.Line,
+			Text: extra.Lines[0],
+			Err:  fmt.Errorf("unexpected section %d, want %d sections", len(sections)+1, len(sections)),
+		}
+	}
+
+	if len(blocks) < len(sections) {
+		pe := &ParseError{
+			Line: 1,
+			Err:  fmt.Errorf("unexpected end of input, found %d of %d sections", len(blocks), len(sections)),
+		}
+		if len(blocks) > 0 {
+			last := blocks[len(blocks)-1]
+			pe.Line = last.Line + len(last.Lines)
+		}
+		return pe
+	}
+
+	for i, block := range blocks {
+		if err = sections[i](block); err != nil {
+			return lineError(err, block.Line, block.Lines[0])
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,92 @@
+package aoc
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadBlocks(t *testing.T) {
+	input := "1\n2\n\n3\n \n\n4\n5\n6\n"
+
+	blocks, err := ReadBlocks(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Block{
+		{Line: 1, Lines: []string{"1", "2"}},
+		{Line: 4, Lines: []string{"3"}},
+		{Line: 7, Lines: []string{"4", "5", "6"}},
+	}
+
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("got %v, want %v", blocks, want)
+	}
+}
+
+// TestParseRows_blank checks that a blank line is one zero row. The parse function of the days that
+// are created by aoc new depends on it, see TestScaffold_compiles in cmd/aoc.
+func TestParseRows_blank(t *testing.T) {
+	rows, err := ParseRows(strings.NewReader("a\n\nb\n"), func(line string) (string, error) {
+		return line, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "", "b"}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %q, want %q", rows, want)
+	}
+}
+
+func TestParser_Blocks(t *testing.T) {
+	p := Parser[int]{ParseFn: strconv.Atoi}
+
+	rows, err := p.Blocks(strings.NewReader("1\n2\n\n3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := [][]int{{1, 2}, {3}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %v, want %v", rows, want)
+	}
+
+	_, err = p.Blocks(strings.NewReader("1\n\n2\nx\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("got error %v, want an error on line 4", err)
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	input := "header\n\nmove 1\nmove 2\n"
+
+	var header string
+	var moves []string
+
+	err := ParseSections(strings.NewReader(input),
+		Section(&header, func(b Block) (string, error) {
+			return b.Lines[0], nil
+		}),
+		Section(&moves, func(b Block) ([]string, error) {
+			return b.Lines, nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header != "header" {
+		t.Errorf("got header %q, want %q", header, "header")
+	}
+	if want := []string{"move 1", "move 2"}; !reflect.DeepEqual(moves, want) {
+		t.Errorf("got moves %v, want %v", moves, want)
+	}
+
+	if err = ParseSections(strings.NewReader(input), Section(&header, func(b Block) (string, error) {
+		return b.Lines[0], nil
+	})); err == nil {
+		t.Error("expected an error for too many sections")
+	}
+}
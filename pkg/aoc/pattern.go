@@ -0,0 +1,221 @@
+package aoc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Pattern decodes lines into a struct of type T. A pattern consists of literal text and fields in braces,
+// the fields refer to the exported fields of T by name, e.g.
+//
+//	Sensor at x={Position.X}, y={Position.Y}: closest beacon is at x={Beacon.X}, y={Beacon.Y}
+//
+// Fields of nested structs are referred to by their path. Supported field types are strings, integers and
+// slices of those. The elements of a slice are separated by ",", another separator is given after a colon,
+// e.g. {Items:, }. Fields must be separated by literal text, the text of a field ends at the first
+// occurrence of the literal that follows it.
+type Pattern[T any] struct {
+	pattern  string
+	literals []string
+	fields   []patternField
+}
+
+type patternField struct {
+	name  string
+	index []int
+	sep   string
+}
+
+// NewPattern compiles the pattern for type T.
+func NewPattern[T any](pattern string) (*Pattern[T], error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pattern %q: %v is not a struct", pattern, typ)
+	}
+
+	p := &Pattern[T]{pattern: pattern}
+
+	rest := pattern
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			p.literals = append(p.literals, rest)
+			break
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("pattern %q: unclosed field", pattern)
+		}
+
+		p.literals = append(p.literals, rest[:open])
+
+		field, err := newPatternField(typ, rest[open+1:open+end])
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		p.fields = append(p.fields, field)
+
+		rest = rest[open+end+1:]
+	}
+
+	for i := 1; i < len(p.fields); i++ {
+		if p.literals[i] == "" {
+			return nil, fmt.Errorf("pattern %q: fields %s and %s are not separated", pattern, p.fields[i-1].name, p.fields[i].name)
+		}
+	}
+
+	return p, nil
+}
+
+// MustPattern is like NewPattern but panics when the pattern is invalid.
+// It simplifies the initialization of global variables holding patterns.
+func MustPattern[T any](pattern string) *Pattern[T] {
+	p, err := NewPattern[T](pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func newPatternField(typ reflect.Type, spec string) (patternField, error) {
+	name, sep, hasSep := strings.Cut(spec, ":")
+	field := patternField{name: name, sep: sep}
+
+	for _, part := range strings.Split(name, ".") {
+		if typ.Kind() != reflect.Struct {
+			return field, fmt.Errorf("field %s: %v is not a struct", name, typ)
+		}
+		sf, ok := typ.FieldByName(part)
+		if !ok || !sf.IsExported() {
+			return field, fmt.Errorf("field %s: %v has no exported field %s", name, typ, part)
+		}
+		field.index = append(field.index, sf.Index...)
+		typ = sf.Type
+	}
+
+	elem := typ
+	if typ.Kind() == reflect.Slice {
+		elem = typ.Elem()
+		if !hasSep {
+			field.sep = ","
+		}
+		if field.sep == "" {
+			return field, fmt.Errorf("field %s: empty separator", name)
+		}
+	} else if hasSep {
+		return field, fmt.Errorf("field %s: separator given for %v", name, typ)
+	}
+
+	if !isScalar(elem.Kind()) {
+		return field, fmt.Errorf("field %s: unsupported type %v", name, typ)
+	}
+
+	return field, nil
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// Parse decodes the line. It is a ParseFn, errors are returned as a *ParseError that points at the
+// offending column.
+func (p *Pattern[T]) Parse(line string) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+
+	if !strings.HasPrefix(line, p.literals[0]) {
+		return v, prefixError(line, p.literals[0])
+	}
+	pos := len(p.literals[0])
+
+	for i, field := range p.fields {
+		next := p.literals[i+1]
+
+		end := len(line)
+		if next != "" {
+			n := strings.Index(line[pos:], next)
+			if n < 0 {
+				return v, ErrorAt(pos+1, fmt.Errorf("expected %q after field %s", next, field.name))
+			}
+			end = pos + n
+		}
+
+		if err := field.set(rv.FieldByIndex(field.index), line[pos:end], pos); err != nil {
+			return v, err
+		}
+
+		pos = end + len(next)
+	}
+
+	if pos != len(line) {
+		return v, ErrorAt(pos+1, fmt.Errorf("unexpected %q", line[pos:]))
+	}
+
+	return v, nil
+}
+
+func (f patternField) set(v reflect.Value, s string, pos int) error {
+	if v.Kind() != reflect.Slice {
+		return f.setScalar(v, s, pos)
+	}
+
+	if s == "" {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		return nil
+	}
+
+	parts := strings.Split(s, f.sep)
+	slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+
+	for i, part := range parts {
+		if err := f.setScalar(slice.Index(i), part, pos); err != nil {
+			return err
+		}
+		pos += len(part) + len(f.sep)
+	}
+
+	v.Set(slice)
+	return nil
+}
+
+func (f patternField) setScalar(v reflect.Value, s string, pos int) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return ErrorAt(pos+1, fmt.Errorf("field %s: %w", f.name, err))
+		}
+		v.SetInt(n)
+	default:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return ErrorAt(pos+1, fmt.Errorf("field %s: %w", f.name, err))
+		}
+		v.SetUint(n)
+	}
+	return nil
+}
+
+// prefixError points at the first byte of the line that differs from the literal it should start with.
+func prefixError(line, literal string) *ParseError {
+	i := 0
+	for i < len(literal) && i < len(line) && line[i] == literal[i] {
+		i++
+	}
+	return ErrorAt(i+1, fmt.Errorf("expected %q", literal))
+}
+
+func (p *Pattern[T]) String() string {
+	return p.pattern
+}
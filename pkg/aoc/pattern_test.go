@@ -0,0 +1,130 @@
+package aoc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testVector struct {
+	X, Y int
+}
+
+type testSensor struct {
+	Position testVector
+	Beacon   testVector
+}
+
+type testValve struct {
+	Name    string
+	Rate    uint8
+	Tunnels []string
+}
+
+func TestPattern_Parse(t *testing.T) {
+	sensor := MustPattern[testSensor]("Sensor at x={Position.X}, y={Position.Y}: closest beacon is at x={Beacon.X}, y={Beacon.Y}")
+
+	got, err := sensor.Parse("Sensor at x=2, y=18: closest beacon is at x=-2, y=15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (testSensor{testVector{2, 18}, testVector{-2, 15}}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	valve := MustPattern[testValve]("Valve {Name} has flow rate={Rate}; tunnels lead to valves {Tunnels:, }")
+
+	v, err := valve.Parse("Valve AA has flow rate=0; tunnels lead to valves DD, II, BB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (testValve{"AA", 0, []string{"DD", "II", "BB"}}); !reflect.DeepEqual(v, want) {
+		t.Errorf("got %v, want %v", v, want)
+	}
+
+	items := MustPattern[struct{ Items []int }]("Starting items: {Items:, }")
+
+	for line, want := range map[string][]int{
+		"Starting items: 79, 98": {79, 98},
+		"Starting items: 74":     {74},
+		"Starting items: ":       {},
+	} {
+		got, err := items.Parse(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.Items, want) {
+			t.Errorf("%q: got %v, want %v", line, got.Items, want)
+		}
+	}
+}
+
+func TestPattern_Parse_errors(t *testing.T) {
+	valve := MustPattern[testValve]("Valve {Name} has flow rate={Rate}; tunnels lead to valves {Tunnels:, }")
+
+	tests := []struct {
+		line    string
+		column  int
+		message string
+	}{
+		{"Value AA has flow rate=0; tunnels lead to valves DD", 4, `expected "Valve "`},
+		{"Valve AA has flow rate=x; tunnels lead to valves DD", 24, `field Rate: strconv.ParseUint: parsing "x": invalid syntax`},
+		{"Valve AA has flow rate=300; tunnels lead to valves DD", 24, `field Rate: strconv.ParseUint: parsing "300": value out of range`},
+		{"Valve AA has flow rate=0; tunnel leads to valve DD", 24, `expected "; tunnels lead to valves " after field Rate`},
+		{"Valve AA", 7, `expected " has flow rate=" after field Name`},
+	}
+
+	for _, tc := range tests {
+		_, err := valve.Parse(tc.line)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("%q: got error %v, want a *ParseError", tc.line, err)
+		}
+		if pe.Column != tc.column || pe.Err.Error() != tc.message {
+			t.Errorf("%q: got column %d %q, want column %d %q", tc.line, pe.Column, pe.Err, tc.column, tc.message)
+		}
+	}
+
+	numbers := MustPattern[struct{ N []int }]("{N:-}.")
+	if _, err := numbers.Parse("1-2-x."); err == nil || !strings.Contains(err.Error(), "column 5") {
+		t.Errorf("got error %v, want an error at column 5", err)
+	}
+	if _, err := numbers.Parse("1-2.3"); err == nil || !strings.Contains(err.Error(), "column 5") {
+		t.Errorf("got error %v, want an error at column 5", err)
+	}
+}
+
+func TestNewPattern_errors(t *testing.T) {
+	for _, pattern := range []string{
+		"{Name",
+		"{Missing}",
+		"{Name}{Rate}",
+		"{Name:, }",
+		"{Tunnels:}",
+		"{Name.X}",
+	} {
+		if _, err := NewPattern[testValve](pattern); err == nil {
+			t.Errorf("%q: expected an error", pattern)
+		}
+	}
+
+	if _, err := NewPattern[int]("{X}"); err == nil {
+		t.Error("expected an error for a non-struct type")
+	}
+}
+
+func TestPattern_parser(t *testing.T) {
+	p := Parser[testVector]{ParseFn: MustPattern[testVector]("{X},{Y}").Parse}
+
+	_, err := p.Rows(strings.NewReader("1,2\n3;4\n"))
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("got error %v, want a *ParseError", err)
+	}
+	if pe.Line != 2 || pe.Column != 1 {
+		t.Errorf("got line %d column %d, want line 2 column 1", pe.Line, pe.Column)
+	}
+}
@@ -0,0 +1,87 @@
+package aoc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PrefetchStatus is the outcome of prefetching the input of a puzzle.
+type PrefetchStatus string
+
+const (
+	// Downloaded means the input was downloaded and cached.
+	Downloaded PrefetchStatus = "downloaded"
+	// Cached means the input was already cached.
+	Cached PrefetchStatus = "cached"
+	// Locked means the puzzle is not released yet.
+	Locked PrefetchStatus = "locked"
+	// Failed means the download failed, see PrefetchResult.Err.
+	Failed PrefetchStatus = "failed"
+)
+
+// PrefetchResult is the outcome of prefetching the input of a single puzzle.
+type PrefetchResult struct {
+	Puzzle   Puzzle
+	Status   PrefetchStatus
+	Err      error
+	Duration time.Duration
+}
+
+// Prefetch downloads the inputs of all puzzles of the given year that are unlocked and not cached yet,
+// so they are available offline. At most workers inputs are downloaded concurrently, the requests are
+// still spaced by MinInterval. It returns a result for every day of the event, in order.
+func (c *Client) Prefetch(ctx context.Context, year, workers int) []PrefetchResult {
+	results := make([]PrefetchResult, 25)
+	for i := range results {
+		results[i] = PrefetchResult{Puzzle: Puzzle{Year: year, Day: i + 1}, Status: Locked}
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.prefetch(ctx, results[i].Puzzle)
+			}
+		}()
+	}
+
+	for _, p := range Unlocked(year, time.Now()) {
+		jobs <- p.Day - 1
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+func (c *Client) prefetch(ctx context.Context, p Puzzle) PrefetchResult {
+	start := time.Now()
+	res := PrefetchResult{Puzzle: p}
+
+	fp, err := c.inputPath(p)
+	switch {
+	case err != nil:
+		res.Status, res.Err = Failed, err
+	case isCached(fp):
+		res.Status = Cached
+	default:
+		if err = c.download(ctx, p, fp); err != nil {
+			res.Status, res.Err = Failed, err
+		} else {
+			res.Status = Downloaded
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res
+}
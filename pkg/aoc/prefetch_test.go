@@ -0,0 +1,100 @@
+package aoc
+
+import (
+	"context"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUnlocked(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2022, time.November, 30, 23, 0, 0, 0, time.UTC), 0},
+		// midnight EST is 05:00 UTC
+		{time.Date(2022, time.December, 1, 4, 59, 59, 0, time.UTC), 0},
+		{time.Date(2022, time.December, 1, 5, 0, 0, 0, time.UTC), 1},
+		{time.Date(2022, time.December, 10, 12, 0, 0, 0, time.UTC), 10},
+		{time.Date(2022, time.December, 25, 5, 0, 0, 0, time.UTC), 25},
+		{time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC), 25},
+	}
+
+	for _, tc := range tests {
+		got := Unlocked(2022, tc.now)
+		if len(got) != tc.want {
+			t.Errorf("%v: got %d unlocked days, want %d", tc.now, len(got), tc.want)
+		}
+		for i, p := range got {
+			if p != Day(i+1) {
+				t.Errorf("%v: got %v, want %v", tc.now, p, Day(i+1))
+			}
+		}
+	}
+
+	if got := Unlocked(2014, time.Now()); len(got) != 0 {
+		t.Errorf("got %d unlocked days before the first event, want 0", len(got))
+	}
+}
+
+func TestClient_Prefetch(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	for _, day := range []string{"02", "03"} {
+		fp := filepath.Join(srv.Dir(), "2022", day, "input.txt")
+		if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fp, []byte(day+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	results := c.Prefetch(context.Background(), 2022, 4)
+	if len(results) != 25 {
+		t.Fatalf("got %d results, want 25", len(results))
+	}
+
+	for i, r := range results {
+		want := Failed // the other days have no fixture
+		switch i + 1 {
+		case 1:
+			want = Cached
+		case 2, 3:
+			want = Downloaded
+		}
+
+		if r.Puzzle != Day(i+1) || r.Status != want {
+			t.Errorf("got %v %s, want %v %s", r.Puzzle, r.Status, Day(i+1), want)
+		}
+		if (r.Err != nil) != (want == Failed) {
+			t.Errorf("%v: unexpected error %v", r.Puzzle, r.Err)
+		}
+	}
+
+	for _, day := range []int{2, 3} {
+		if _, err := c.Cached(Day(day)); err != nil {
+			t.Errorf("expected day %d to be cached: %v", day, err)
+		}
+	}
+
+	requests := len(srv.Requests())
+
+	future := time.Now().Year() + 1
+	for _, r := range c.Prefetch(context.Background(), future, 4) {
+		if r.Status != Locked {
+			t.Errorf("got %v %s, want %s", r.Puzzle, r.Status, Locked)
+		}
+	}
+
+	if n := len(srv.Requests()); n != requests {
+		t.Errorf("got %d requests for locked days, want none", n-requests)
+	}
+}
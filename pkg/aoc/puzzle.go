@@ -0,0 +1,60 @@
+package aoc
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultYear is the event this repository solves, it is used by Get and Load.
+	DefaultYear = 2022
+
+	firstYear = 2015
+)
+
+// releaseZone is the time zone of the release schedule, puzzles unlock at midnight EST (UTC-5).
+var releaseZone = time.FixedZone("UTC-5", -5*60*60)
+
+// Puzzle identifies the puzzle of a single day of an event.
+type Puzzle struct {
+	Year int
+	Day  int
+}
+
+// Day returns the puzzle for the given day of the DefaultYear.
+func Day(day int) Puzzle {
+	return Puzzle{Year: DefaultYear, Day: day}
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("%d day %d", p.Year, p.Day)
+}
+
+// Validate returns an error when the puzzle can not exist.
+func (p Puzzle) Validate() error {
+	if p.Year < firstYear {
+		return fmt.Errorf("invalid year %d: the first event was in %d", p.Year, firstYear)
+	}
+	if p.Day < 1 || p.Day > 25 {
+		return fmt.Errorf("invalid day %d: must be between 1 and 25", p.Day)
+	}
+	return nil
+}
+
+// Unlock returns the time the puzzle is released.
+func (p Puzzle) Unlock() time.Time {
+	return time.Date(p.Year, time.December, p.Day, 0, 0, 0, 0, releaseZone)
+}
+
+// Unlocked returns the puzzles of the given year that are released at the given time.
+func Unlocked(year int, now time.Time) []Puzzle {
+	var puzzles []Puzzle
+	for day := 1; day <= 25; day++ {
+		p := Puzzle{Year: year, Day: day}
+		if p.Validate() != nil || now.Before(p.Unlock()) {
+			break
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles
+}
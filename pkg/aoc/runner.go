@@ -0,0 +1,141 @@
+package aoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the outcome of running a single part of a solution.
+type Report struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer,omitempty"`
+	Err      error         `json:"-"`
+	Parse    time.Duration `json:"parse_ns"`
+	Duration time.Duration `json:"duration_ns"`
+	Allocs   uint64        `json:"allocs"`
+	Bytes    uint64        `json:"bytes"`
+}
+
+// Run parses the input and runs the given part of the solver. Panics of the solver are
+// recovered and reported as errors. The allocations of parsing and solving are both reported.
+// The day is filled in on a *ParseError that is returned by the solver.
+func Run(day int, s Solver, part int, input []byte) Report {
+	report := Report{Day: day, Part: part}
+
+	var fn func(any) (any, error)
+	switch part {
+	case 1:
+		fn = s.Part1
+	case 2:
+		fn = s.Part2
+	default:
+		report.Err = fmt.Errorf("invalid part %d", part)
+		return report
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	parsed, err := call[io.Reader](s.Parse, bytes.NewReader(input))
+
+	report.Parse = time.Since(start)
+
+	var answer any
+	if err == nil {
+		start = time.Now()
+		answer, err = call(fn, parsed)
+		report.Duration = time.Since(start)
+	}
+
+	runtime.ReadMemStats(&after)
+	report.Allocs = after.Mallocs - before.Mallocs
+	report.Bytes = after.TotalAlloc - before.TotalAlloc
+
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) && pe.Day == 0 {
+			pe.Day = day
+		}
+		report.Err = err
+		return report
+	}
+
+	report.Answer = fmt.Sprint(answer)
+	return report
+}
+
+// ErrNoRenderer is returned by Render for solvers that do not implement Renderer.
+var ErrNoRenderer = errors.New("no renderer")
+
+// Render parses the input and renders it with the solver, which must implement Renderer.
+// The outputs are written with the client. Panics of the renderer are recovered and returned as errors.
+func Render(c *Client, s Solver, input []byte) error {
+	r, ok := s.(Renderer)
+	if !ok {
+		return ErrNoRenderer
+	}
+
+	parsed, err := call[io.Reader](s.Parse, bytes.NewReader(input))
+	if err != nil {
+		return err
+	}
+
+	_, err = call(func(in any) (any, error) {
+		return nil, r.Render(c, in)
+	}, parsed)
+	return err
+}
+
+func call[In any](fn func(In) (any, error), in In) (_ any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(in)
+}
+
+// MarshalJSON adds the error message to the JSON representation of the report.
+func (r Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	var msg string
+	if r.Err != nil {
+		msg = r.Err.Error()
+	}
+	return json.Marshal(struct {
+		report
+		Error string `json:"error,omitempty"`
+	}{report(r), msg})
+}
+
+// WriteTable writes the reports as an aligned table.
+func WriteTable(w io.Writer, reports []Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	_, _ = fmt.Fprintln(tw, "Day\tPart\tAnswer\tParse\tSolve\tAllocs\tBytes\t")
+	for _, r := range reports {
+		answer := r.Answer
+		if r.Err != nil {
+			answer = "error: " + r.Err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%s\t%v\t%v\t%d\t%d\t\n", r.Day, r.Part, answer, r.Parse.Round(time.Microsecond), r.Duration.Round(time.Microsecond), r.Allocs, r.Bytes)
+	}
+
+	return tw.Flush()
+}
+
+// WriteJSON writes the reports as a JSON array.
+func WriteJSON(w io.Writer, reports []Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
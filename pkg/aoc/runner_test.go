@@ -0,0 +1,94 @@
+package aoc
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	errBad := errors.New("bad input")
+
+	parse := func(r io.Reader) ([]byte, error) {
+		return io.ReadAll(r)
+	}
+	length := func(b []byte) int {
+		return len(b)
+	}
+	fail := func(b []byte) (string, error) {
+		return "", errBad
+	}
+
+	s := NewSolution(parse, Infallible(length), fail)
+
+	if r := Run(1, s, 1, []byte("abc")); r.Err != nil || r.Answer != "3" {
+		t.Errorf("got %q (%v), want 3", r.Answer, r.Err)
+	}
+
+	if r := Run(1, s, 2, nil); !errors.Is(r.Err, errBad) {
+		t.Errorf("got error %v, want %v", r.Err, errBad)
+	}
+
+	s.Part2Fn = nil
+	if r := Run(1, s, 2, nil); !errors.Is(r.Err, ErrNotSolved) {
+		t.Errorf("got error %v, want %v", r.Err, ErrNotSolved)
+	}
+
+	s.Part1Fn = func(b []byte) (int, error) {
+		panic("boom")
+	}
+	if r := Run(1, s, 1, nil); r.Err == nil || !strings.Contains(r.Err.Error(), "boom") {
+		t.Errorf("expected the panic to be reported as an error, got %v", r.Err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	parse := func(r io.Reader) (string, error) {
+		b, err := io.ReadAll(r)
+		return string(b), err
+	}
+	s := NewSolution[string, int, int](parse, nil, nil)
+
+	c := &Client{Dir: t.TempDir()}
+	if err := Render(c, s, nil); !errors.Is(err, ErrNoRenderer) {
+		t.Errorf("got error %v, want %v", err, ErrNoRenderer)
+	}
+
+	r := s.WithRender(func(c *Client, in string) error {
+		return c.SaveOutput(1, "input.txt", in)
+	})
+	if err := Render(c, r, []byte("abc")); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := c.Outputs(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 1 || outputs[0].Name != "input.txt" || outputs[0].Size != 3 {
+		t.Errorf("got outputs %+v, want input.txt of 3 bytes", outputs)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s := NewSolution[int, int, int](func(r io.Reader) (int, error) { return 0, nil }, nil, nil)
+
+	Register(25, s)
+	defer func() {
+		registryMu.Lock()
+		delete(registry, 25)
+		registryMu.Unlock()
+	}()
+
+	if _, ok := Lookup(25); !ok {
+		t.Errorf("expected day 25 to be registered")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected registering a day twice to panic")
+		}
+	}()
+	Register(25, s)
+}
@@ -0,0 +1,212 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SessionEnv is the environment variable that holds the session cookie.
+	SessionEnv = "AOC_SESSION"
+
+	sessionFile       = "cookie.txt"
+	configSessionPath = "aoc/session"
+
+	// minSessionLength is the minimal length of a session cookie, the website currently
+	// hands out cookies of 96 or 128 hexadecimal characters.
+	minSessionLength = 32
+)
+
+var (
+	// ErrNoSession is returned when no session cookie is configured.
+	ErrNoSession = errors.New("no session cookie found")
+	// ErrInvalidSession is returned for session cookies that are malformed.
+	ErrInvalidSession = errors.New("invalid session cookie")
+	// ErrSessionExpired is returned when the website rejects the session cookie.
+	ErrSessionExpired = errors.New("session expired: log in to the website and update the session cookie")
+)
+
+// SessionProvider provides the session cookie. It returns an error that wraps ErrNoSession
+// when it has none.
+type SessionProvider interface {
+	Session() (string, error)
+}
+
+// noSessionError is ErrNoSession with the source that was searched.
+type noSessionError struct {
+	source string
+}
+
+func (e noSessionError) Error() string {
+	if e.source == "" {
+		return ErrNoSession.Error()
+	}
+	return fmt.Sprintf("%v in %s", ErrNoSession, e.source)
+}
+
+func (e noSessionError) Is(target error) bool {
+	return target == ErrNoSession
+}
+
+// SessionFunc is a function that acts as a SessionProvider.
+type SessionFunc func() (string, error)
+
+func (f SessionFunc) Session() (string, error) {
+	return f()
+}
+
+// EnvSession provides the session cookie from the environment variable with the given name.
+func EnvSession(name string) SessionProvider {
+	return SessionFunc(func() (string, error) {
+		s := os.Getenv(name)
+		if strings.TrimSpace(s) == "" {
+			return "", noSessionError{"$" + name}
+		}
+		return s, nil
+	})
+}
+
+// FileSession provides the session cookie from the file at path.
+func FileSession(path string) SessionProvider {
+	return SessionFunc(func() (string, error) {
+		b, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", noSessionError{path}
+		}
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	})
+}
+
+// ConfigSession provides the session cookie from the file aoc/session in the user configuration
+// directory, e.g. ~/.config/aoc/session on Linux.
+func ConfigSession() SessionProvider {
+	return SessionFunc(func() (string, error) {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrNoSession, err)
+		}
+		return FileSession(filepath.Join(dir, configSessionPath)).Session()
+	})
+}
+
+// SessionChain provides the session cookie of the first provider that has one.
+func SessionChain(providers ...SessionProvider) SessionProvider {
+	return SessionFunc(func() (string, error) {
+		var sources []string
+
+		for _, p := range providers {
+			s, err := p.Session()
+			if err == nil {
+				return s, nil
+			}
+			if !errors.Is(err, ErrNoSession) {
+				return "", err
+			}
+
+			var nse noSessionError
+			if errors.As(err, &nse) {
+				sources = append(sources, nse.source)
+			}
+		}
+
+		return "", noSessionError{strings.Join(sources, ", ")}
+	})
+}
+
+// ParseSession trims the session cookie and validates its format. The name of the cookie
+// may be included, as copied from the developer tools of a browser.
+func ParseSession(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "session=")
+
+	if len(s) < minSessionLength {
+		return "", fmt.Errorf("%w: too short, expected at least %d characters", ErrInvalidSession, minSessionLength)
+	}
+
+	for i, r := range s {
+		if !isHex(r) {
+			return "", fmt.Errorf("%w: unexpected %q at position %d, expected a hexadecimal string", ErrInvalidSession, r, i+1)
+		}
+	}
+
+	return s, nil
+}
+
+func isHex(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+}
+
+// cookie returns the session cookie. Client.Session takes precedence over Client.Sessions, which
+// defaults to the environment variable, the user configuration and cookie.txt in Dir, in that order.
+func (c *Client) cookie() (string, error) {
+	if c.Session != "" {
+		return ParseSession(c.Session)
+	}
+
+	provider := c.Sessions
+	if provider == nil {
+		dir, err := c.dir()
+		if err != nil {
+			return "", err
+		}
+		provider = SessionChain(
+			EnvSession(SessionEnv),
+			ConfigSession(),
+			FileSession(filepath.Join(dir, sessionFile)),
+		)
+	}
+
+	s, err := provider.Session()
+	if err != nil {
+		return "", err
+	}
+
+	return ParseSession(s)
+}
+
+// loginMarkers are the texts of the website that ask the user to log in, in lower case.
+var loginMarkers = []string{"please log in", "please identify yourself"}
+
+// isLoginPrompt reports whether the body of a response asks the user to log in.
+func isLoginPrompt(b []byte) bool {
+	lower := strings.ToLower(string(b))
+	for _, m := range loginMarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLogin returns ErrSessionExpired when the body of a response asks the user to log in.
+// The website does so with status 200 for puzzle pages and answers.
+func checkLogin(b []byte) error {
+	if isLoginPrompt(b) {
+		return ErrSessionExpired
+	}
+	return nil
+}
+
+// responseError returns the error for a response of the website that is not OK.
+// Rejected session cookies result in ErrSessionExpired.
+func responseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode == http.StatusBadRequest || isLoginPrompt(b) {
+		return ErrSessionExpired
+	}
+
+	return fmt.Errorf("status code %d", resp.StatusCode)
+}
@@ -0,0 +1,136 @@
+package aoc
+
+import (
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateSession hides the session cookie of the user from the default providers.
+func isolateSession(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv(SessionEnv, "")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestParseSession(t *testing.T) {
+	valid := strings.Repeat("0123456789abcdef", 6)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}{
+		{"valid", valid, valid, nil},
+		{"trailing newline", valid + "\n", valid, nil},
+		{"cookie name", " session=" + valid + " ", valid, nil},
+		{"empty", "", "", ErrInvalidSession},
+		{"short", "abc", "", ErrInvalidSession},
+		{"not hex", valid[:40] + "xyz", "", ErrInvalidSession},
+		{"html", "<html><body>" + valid, "", ErrInvalidSession},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseSession(tc.input)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClient_cookie(t *testing.T) {
+	config := isolateSession(t)
+
+	c := &Client{Dir: t.TempDir()}
+
+	_, err := c.cookie()
+	if !errors.Is(err, ErrNoSession) {
+		t.Fatalf("got error %v, want %v", err, ErrNoSession)
+	}
+	for _, source := range []string{"$" + SessionEnv, configSessionPath, sessionFile} {
+		if !strings.Contains(err.Error(), source) {
+			t.Errorf("error %q does not mention %s", err, source)
+		}
+	}
+
+	write := func(path, session string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(session+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fromFile := strings.Repeat("a", 96)
+	fromConfig := strings.Repeat("b", 96)
+	fromEnv := strings.Repeat("c", 96)
+
+	steps := []struct {
+		setup func()
+		want  string
+	}{
+		{func() { write(filepath.Join(c.Dir, sessionFile), fromFile) }, fromFile},
+		{func() { write(filepath.Join(config, configSessionPath), fromConfig) }, fromConfig},
+		{func() { t.Setenv(SessionEnv, fromEnv) }, fromEnv},
+	}
+
+	for _, step := range steps {
+		step.setup()
+
+		got, err := c.cookie()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != step.want {
+			t.Errorf("got %q, want %q", got, step.want)
+		}
+	}
+
+	c.Sessions = SessionFunc(func() (string, error) {
+		return "not a session", nil
+	})
+	if _, err = c.cookie(); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidSession)
+	}
+}
+
+func TestClient_expiredSession(t *testing.T) {
+	c, _ := newTestClient(t, strings.Repeat("0", 96))
+
+	if _, err := c.Get(Day(1)); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("get: got error %v, want %v", err, ErrSessionExpired)
+	}
+
+	if _, err := c.RefreshPage(Day(1)); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("page: got error %v, want %v", err, ErrSessionExpired)
+	}
+
+	if _, err := c.Submit(Day(1), 1, "24000"); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("submit: got error %v, want %v", err, ErrSessionExpired)
+	}
+
+	c.Session = aoctest.Session
+	if _, err := c.Get(Day(1)); err != nil {
+		t.Errorf("got error %v after updating the session", err)
+	}
+}
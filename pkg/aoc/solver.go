@@ -0,0 +1,154 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// ErrNotSolved is returned for parts that have no solver.
+var ErrNotSolved = errors.New("not solved")
+
+// Solver solves the puzzle of a single day.
+// Parse is called before each part, so a part may modify its input.
+type Solver interface {
+	Parse(r io.Reader) (any, error)
+	Part1(input any) (any, error)
+	Part2(input any) (any, error)
+}
+
+// Solution is a Solver built from typed functions. Input is the type of the parsed input,
+// Answer1 and Answer2 are the types of the answers. A nil Part1Fn or Part2Fn reports ErrNotSolved.
+type Solution[Input, Answer1, Answer2 any] struct {
+	ParseFn func(io.Reader) (Input, error)
+	Part1Fn func(Input) (Answer1, error)
+	Part2Fn func(Input) (Answer2, error)
+}
+
+// NewSolution returns a Solution for the given functions.
+func NewSolution[Input, Answer1, Answer2 any](
+	parse func(io.Reader) (Input, error),
+	part1 func(Input) (Answer1, error),
+	part2 func(Input) (Answer2, error),
+) Solution[Input, Answer1, Answer2] {
+	return Solution[Input, Answer1, Answer2]{
+		ParseFn: parse,
+		Part1Fn: part1,
+		Part2Fn: part2,
+	}
+}
+
+func (s Solution[Input, Answer1, Answer2]) Parse(r io.Reader) (any, error) {
+	return s.ParseFn(r)
+}
+
+func (s Solution[Input, Answer1, Answer2]) Part1(input any) (any, error) {
+	if s.Part1Fn == nil {
+		return nil, ErrNotSolved
+	}
+	in, err := inputOf[Input](input)
+	if err != nil {
+		return nil, err
+	}
+	return s.Part1Fn(in)
+}
+
+func (s Solution[Input, Answer1, Answer2]) Part2(input any) (any, error) {
+	if s.Part2Fn == nil {
+		return nil, ErrNotSolved
+	}
+	in, err := inputOf[Input](input)
+	if err != nil {
+		return nil, err
+	}
+	return s.Part2Fn(in)
+}
+
+// Renderer is implemented by solvers that can render their input, such as images of a simulation.
+// Rendering is not a part of solving: it is only done on request, e.g. with aoc run -render,
+// and writes to the output directory of the day of the client.
+type Renderer interface {
+	Render(c *Client, input any) error
+}
+
+// WithRender returns a Solver that also implements Renderer with the given function.
+func (s Solution[Input, Answer1, Answer2]) WithRender(render func(c *Client, input Input) error) Solver {
+	return renderedSolution[Input, Answer1, Answer2]{Solution: s, renderFn: render}
+}
+
+type renderedSolution[Input, Answer1, Answer2 any] struct {
+	Solution[Input, Answer1, Answer2]
+	renderFn func(*Client, Input) error
+}
+
+func (s renderedSolution[Input, Answer1, Answer2]) Render(c *Client, input any) error {
+	in, err := inputOf[Input](input)
+	if err != nil {
+		return err
+	}
+	return s.renderFn(c, in)
+}
+
+func inputOf[Input any](input any) (Input, error) {
+	in, ok := input.(Input)
+	if !ok {
+		return in, fmt.Errorf("unexpected input type %T, want %T", input, in)
+	}
+	return in, nil
+}
+
+// Infallible adapts a function that can not fail to the signature of a part of a Solution.
+func Infallible[Input, Answer any](fn func(Input) Answer) func(Input) (Answer, error) {
+	return func(in Input) (Answer, error) {
+		return fn(in), nil
+	}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[int]Solver)
+)
+
+// Register makes the solver of the given day available to the runner.
+// It is meant to be called from the init function of the package of the day
+// and panics when the day is invalid or already registered.
+func Register(day int, s Solver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if err := Day(day).Validate(); err != nil {
+		panic(fmt.Sprintf("aoc: register: %v", err))
+	}
+	if s == nil {
+		panic(fmt.Sprintf("aoc: register: solver of day %d is nil", day))
+	}
+	if _, dup := registry[day]; dup {
+		panic(fmt.Sprintf("aoc: register: day %d is registered twice", day))
+	}
+
+	registry[day] = s
+}
+
+// Lookup returns the solver that was registered for the given day.
+func Lookup(day int) (Solver, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	s, ok := registry[day]
+	return s, ok
+}
+
+// Days returns the registered days in ascending order.
+func Days() []int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	days := make([]int, 0, len(registry))
+	for day := range registry {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
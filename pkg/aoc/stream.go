@@ -0,0 +1,139 @@
+package aoc
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+)
+
+// Open returns the input of the given puzzle as a stream, it is downloaded when it is not cached yet.
+// Reads fail with the error of the context once it is done. The caller must close the stream.
+func (c *Client) Open(ctx context.Context, p Puzzle) (io.ReadCloser, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	fp, err := c.inputPath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCached(fp) {
+		if err = c.download(ctx, p, fp); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctxReader{ctx: ctx, ReadCloser: f}, nil
+}
+
+// Lines returns an iterator over the lines of the input of the given puzzle.
+// The caller must close the iterator.
+func (c *Client) Lines(ctx context.Context, p Puzzle) (*Lines, error) {
+	rc, err := c.Open(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return NewLines(ctx, rc), nil
+}
+
+// ctxReader fails reads once its context is done.
+type ctxReader struct {
+	ctx context.Context
+	io.ReadCloser
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
+
+// maxLineLength is the length of the longest line of an input that is accepted, generated inputs
+// may have lines that are far longer than the 64 KiB that bufio.Scanner accepts by default.
+const maxLineLength = 64 << 20
+
+// newScanner returns a scanner over the lines of r that accepts lines up to maxLineLength.
+// Its buffer only grows as far as the longest line needs.
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	return scanner
+}
+
+// Lines iterates over the lines of an input, without reading the whole input in memory.
+//
+//	lines := aoc.NewLines(ctx, r)
+//	defer lines.Close()
+//	for lines.Next() {
+//		fmt.Println(lines.Line(), lines.Text())
+//	}
+//	if err := lines.Err(); err != nil {
+//		...
+//	}
+type Lines struct {
+	ctx     context.Context
+	reader  io.Reader
+	scanner *bufio.Scanner
+	line    int
+	err     error
+}
+
+// NewLines returns an iterator over the lines of r. Iteration stops when the context is done.
+func NewLines(ctx context.Context, r io.Reader) *Lines {
+	return &Lines{
+		ctx:     ctx,
+		reader:  r,
+		scanner: newScanner(r),
+	}
+}
+
+// Next advances to the next line, it returns false at the end of the input or on an error.
+func (l *Lines) Next() bool {
+	if l.err != nil {
+		return false
+	}
+
+	if err := l.ctx.Err(); err != nil {
+		l.err = err
+		return false
+	}
+
+	if !l.scanner.Scan() {
+		l.err = l.scanner.Err()
+		return false
+	}
+
+	l.line++
+	return true
+}
+
+// Text returns the current line.
+func (l *Lines) Text() string {
+	return l.scanner.Text()
+}
+
+// Line returns the number of the current line, counting from 1.
+func (l *Lines) Line() int {
+	return l.line
+}
+
+// Err returns the error that stopped the iteration, it is nil at the end of the input.
+func (l *Lines) Err() error {
+	return l.err
+}
+
+// Close closes the underlying reader when it is an io.Closer.
+func (l *Lines) Close() error {
+	if c, ok := l.reader.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
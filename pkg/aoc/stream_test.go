@@ -0,0 +1,188 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClient_Open(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rc, err := c.Open(ctx, Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := make([]byte, 4)
+	if _, err = io.ReadFull(rc, b); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != fixtureInput[:4] {
+		t.Errorf("got %q, want %q", b, fixtureInput[:4])
+	}
+
+	cancel()
+
+	if _, err = rc.Read(b); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	if err = rc.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClient_Open_canceled(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Open(ctx, Day(1)); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+func TestClient_Lines(t *testing.T) {
+	c, _ := newTestClient(t, aoctest.Session)
+
+	lines, err := c.Lines(context.Background(), Day(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = lines.Close()
+	}()
+
+	var got []string
+	for lines.Next() {
+		got = append(got, strconv.Itoa(lines.Line())+":"+lines.Text())
+	}
+	if err = lines.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "1:1000 2:2000 3: 4:3000"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+// countingReader generates n lines with increasing numbers.
+type countingReader struct {
+	n, i int
+	buf  []byte
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.i == r.n {
+			return 0, io.EOF
+		}
+		r.i++
+		r.buf = strconv.AppendInt(r.buf, int64(r.i), 10)
+		r.buf = append(r.buf, '\n')
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestParser_Iter(t *testing.T) {
+	const n = 100_000
+
+	p := Parser[int]{ParseFn: strconv.Atoi}
+	it := p.Iter(context.Background(), &countingReader{n: n})
+
+	var sum int
+	for it.Next() {
+		sum += it.Row()
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := n * (n + 1) / 2; sum != want {
+		t.Errorf("got %d, want %d", sum, want)
+	}
+}
+
+func TestParser_Iter_errors(t *testing.T) {
+	p := Parser[int]{ParseFn: strconv.Atoi}
+
+	it := p.Iter(context.Background(), strings.NewReader("1\n2\nx\n4\n"))
+
+	var rows int
+	for it.Next() {
+		rows++
+	}
+
+	var pe *ParseError
+	if !errors.As(it.Err(), &pe) || pe.Line != 3 {
+		t.Errorf("got error %v, want a *ParseError on line 3", it.Err())
+	}
+	if rows != 2 {
+		t.Errorf("got %d rows, want 2", rows)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	it = p.Iter(ctx, &countingReader{n: 10})
+	for it.Next() {
+		if it.Row() == 5 {
+			cancel()
+		}
+	}
+	if !errors.Is(it.Err(), context.Canceled) || it.Row() != 5 {
+		t.Errorf("got error %v after row %d, want %v after row 5", it.Err(), it.Row(), context.Canceled)
+	}
+}
+
+func TestLines_long(t *testing.T) {
+	// longer than the 64 KiB that bufio.Scanner accepts by default
+	long := strings.Repeat("x", 1<<20)
+	input := "a\n" + long + "\n\nb\n"
+
+	lines := NewLines(context.Background(), strings.NewReader(input))
+	var got []string
+	for lines.Next() {
+		got = append(got, lines.Text())
+	}
+	if err := lines.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 || got[1] != long {
+		t.Errorf("got %d lines, want 4 with the long line second", len(got))
+	}
+
+	rows, err := ParseRows(strings.NewReader(input), func(line string) (int, error) {
+		return len(line), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || rows[1] != len(long) {
+		t.Errorf("got rows %v, want the length of the long line second", rows)
+	}
+
+	blocks, err := ReadBlocks(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || blocks[0].Lines[1] != long {
+		t.Errorf("got %d blocks, want 2 with the long line in the first", len(blocks))
+	}
+}
@@ -0,0 +1,183 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const answerURLFormat = "%s/%d/day/%d/answer"
+
+// Verdict is the judgement of a submitted answer.
+type Verdict string
+
+const (
+	Correct     Verdict = "correct"
+	TooHigh     Verdict = "too high"
+	TooLow      Verdict = "too low"
+	Wrong       Verdict = "wrong"
+	RateLimited Verdict = "rate limited"
+	WrongLevel  Verdict = "wrong level"
+)
+
+// Result is the response to a submitted answer.
+type Result struct {
+	Verdict Verdict
+	// Wait is the time left to wait before a new answer is accepted, it is only set when rate limited.
+	Wait time.Duration
+	// Message is the text of the response page.
+	Message string
+}
+
+var (
+	articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex     = regexp.MustCompile(`<[^>]*>`)
+	waitRegex    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// Submit submits the answer for the given part of the puzzle. Every attempt is recorded in the
+// ledger in Dir. Answers that were rejected before, or that are outside the bounds given by
+// earlier too high and too low verdicts, are refused without contacting the website.
+func (c *Client) Submit(p Puzzle, part int, answer string) (Result, error) {
+	if err := p.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	if part != 1 && part != 2 {
+		return Result{}, fmt.Errorf("invalid part %d: must be 1 or 2", part)
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return Result{}, fmt.Errorf("empty answer")
+	}
+
+	dir, err := c.dir()
+	if err != nil {
+		return Result{}, err
+	}
+
+	ledger, err := OpenLedger(filepath.Join(dir, ledgerPath))
+	if err != nil {
+		return Result{}, err
+	}
+
+	known, err := ledger.Check(p, part, answer)
+	if err != nil {
+		return Result{}, err
+	}
+	if known != nil {
+		return Result{Verdict: Correct, Message: "answer was already accepted"}, nil
+	}
+
+	res, err := c.postAnswer(p, part, answer)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ledger.Record(Attempt{
+		Year:    p.Year,
+		Day:     p.Day,
+		Part:    part,
+		Answer:  answer,
+		Verdict: res.Verdict,
+		Time:    time.Now(),
+	})
+
+	return res, ledger.Save()
+}
+
+func (c *Client) postAnswer(p Puzzle, part int, answer string) (_ Result, err error) {
+	remote := fmt.Sprintf(answerURLFormat, c.baseURL(), p.Year, p.Day)
+
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+
+	req, err := http.NewRequest("POST", remote, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	defer func() {
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if err = responseError(resp); err != nil {
+		return Result{}, err
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err = checkLogin(b); err != nil {
+		return Result{}, err
+	}
+
+	return parseResult(string(b))
+}
+
+// parseResult parses the page that is returned after submitting an answer.
+func parseResult(page string) (Result, error) {
+	m := articleRegex.FindStringSubmatch(page)
+	if m == nil {
+		return Result{}, fmt.Errorf("unexpected response: no article found")
+	}
+
+	msg := strings.TrimSpace(htmlText(m[1]))
+	res := Result{Message: msg}
+
+	switch {
+	case strings.HasPrefix(msg, "That's the right answer"):
+		res.Verdict = Correct
+	case strings.HasPrefix(msg, "You gave an answer too recently"):
+		res.Verdict = RateLimited
+		res.Wait = parseWait(msg)
+	case strings.HasPrefix(msg, "You don't seem to be solving the right level"):
+		res.Verdict = WrongLevel
+	case strings.HasPrefix(msg, "That's not the right answer"):
+		switch {
+		case strings.Contains(msg, "your answer is too high"):
+			res.Verdict = TooHigh
+		case strings.Contains(msg, "your answer is too low"):
+			res.Verdict = TooLow
+		default:
+			res.Verdict = Wrong
+		}
+	default:
+		return Result{}, fmt.Errorf("unexpected response: %q", msg)
+	}
+
+	return res, nil
+}
+
+func parseWait(msg string) time.Duration {
+	m := waitRegex.FindStringSubmatch(msg)
+	if m == nil {
+		return 0
+	}
+
+	mins, _ := strconv.Atoi(m[1])
+	secs, _ := strconv.Atoi(m[2])
+
+	return time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+}
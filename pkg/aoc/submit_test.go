@@ -0,0 +1,111 @@
+package aoc
+
+import (
+	"errors"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc/aoctest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_parseResult(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want Result
+	}{
+		{
+			name: "correct",
+			page: "<main><article><p>That&#39;s the right answer!  You are <em>one gold star</em> closer.</p></article></main>",
+			want: Result{Verdict: Correct},
+		},
+		{
+			name: "too high",
+			page: "<article><p>That's not the right answer; your answer is too high.</p></article>",
+			want: Result{Verdict: TooHigh},
+		},
+		{
+			name: "too low",
+			page: "<article><p>That's not the right answer; your answer is too low.</p></article>",
+			want: Result{Verdict: TooLow},
+		},
+		{
+			name: "wrong",
+			page: "<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article>",
+			want: Result{Verdict: Wrong},
+		},
+		{
+			name: "rate limited",
+			page: "<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.</p></article>",
+			want: Result{Verdict: RateLimited, Wait: time.Minute + 5*time.Second},
+		},
+		{
+			name: "wrong level",
+			page: "<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>",
+			want: Result{Verdict: WrongLevel},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResult(tt.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Verdict != tt.want.Verdict || got.Wait != tt.want.Wait {
+				t.Errorf("got %s (%v), want %s (%v)", got.Verdict, got.Wait, tt.want.Verdict, tt.want.Wait)
+			}
+		})
+	}
+
+	if _, err := parseResult("<html></html>"); err == nil {
+		t.Errorf("expected an error for a page without an article")
+	}
+}
+
+func TestClient_Submit(t *testing.T) {
+	c, srv := newTestClient(t, aoctest.Session)
+
+	fp := filepath.Join(srv.Dir(), "2022", "01", "answers.txt")
+	if err := os.WriteFile(fp, []byte("24000\n45000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(part int, answer string, want Verdict, wantErr error) {
+		t.Helper()
+
+		res, err := c.Submit(Day(1), part, answer)
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("submit %s: got error %v, want %v", answer, err, wantErr)
+		}
+		if res.Verdict != want {
+			t.Fatalf("submit %s: got %q, want %q", answer, res.Verdict, want)
+		}
+	}
+
+	submit(1, "30000", TooHigh, nil)
+	submit(1, "30000", "", ErrKnownWrong)
+	submit(1, "31000", "", ErrOutOfBounds)
+	submit(1, "20000", TooLow, nil)
+	submit(1, "19000", "", ErrOutOfBounds)
+	submit(1, "24000", Correct, nil)
+	submit(1, "24000", Correct, nil)
+	submit(1, "25000", "", ErrAlreadySolved)
+
+	// the repeated correct answer and the refused answers never reach the server
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	ledger, err := OpenLedger(filepath.Join(c.Dir, ledgerPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(ledger.Attempts(Day(1), 1)); n != 3 {
+		t.Errorf("expected 3 recorded attempts, got %d", n)
+	}
+
+	srv.SetCooldown(time.Minute)
+	submit(2, "1", TooLow, nil)
+	submit(2, "45000", RateLimited, nil)
+}
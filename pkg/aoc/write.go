@@ -1,44 +1,285 @@
 package aoc
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
 	"io"
-	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 )
 
-// SaveOutput saves the output to a file within the folder of the given day.
-// It does not return errors, but logs them instead. This is to prevent
-// cluttering the code with error handling, as this function is never critical.
-func SaveOutput(day int, path string, writeFn func(writer io.Writer) error) {
+const (
+	outputDirFormat = "days/%02d/output"
+	// outputIndexFile lists the artifacts in the output directory of a day.
+	outputIndexFile = "index.json"
+)
+
+// ErrNoEncoder is returned when there is no encoder for the extension of an output file.
+var ErrNoEncoder = errors.New("no encoder for the file extension")
+
+// Encoder encodes a value into the format of a file extension.
+type Encoder interface {
+	Encode(w io.Writer, v any) error
+}
+
+// EncoderFunc is an Encoder of values of type T, other values are rejected.
+type EncoderFunc[T any] func(w io.Writer, v T) error
+
+func (f EncoderFunc[T]) Encode(w io.Writer, v any) error {
+	t, ok := v.(T)
+	if !ok {
+		return fmt.Errorf("cannot encode %T, want %s", v, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return f(w, t)
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		".png":  EncoderFunc[image.Image](png.Encode),
+		".gif":  EncoderFunc[*gif.GIF](gif.EncodeAll),
+		".svg":  EncoderFunc[image.Image](encodeSVG),
+		".txt":  EncoderFunc[any](encodeText),
+		".json": EncoderFunc[any](encodeJSON),
+	}
+)
+
+// RegisterEncoder sets the encoder of output files with the given extension, e.g. ".png".
+// The default encoders are:
+//   - .png encodes an image.Image.
+//   - .gif encodes an animated *gif.GIF.
+//   - .svg encodes an image.Image, with a square per pixel.
+//   - .txt encodes a string, []byte or fmt.Stringer.
+//   - .json encodes any value as indented JSON.
+func RegisterEncoder(ext string, enc Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	encoders[strings.ToLower(ext)] = enc
+}
+
+func encoderFor(name string) (Encoder, error) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	ext := strings.ToLower(filepath.Ext(name))
+	enc, ok := encoders[ext]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrNoEncoder, ext)
+	}
+	return enc, nil
+}
+
+// Artifact is a file in the output directory of a day.
+type Artifact struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
 
-	wd, err := os.Getwd()
+// outputMu serializes the updates of the output indexes.
+var outputMu sync.Mutex
+
+// SaveOutput saves the value to the output directory of the given day, with the encoder of the
+// extension of name, using the DefaultClient.
+func SaveOutput(day int, name string, v any) error {
+	return DefaultClient.SaveOutput(day, name, v)
+}
+
+// SaveOutput saves the value to days/XX/output in Dir, with the encoder of the extension of name.
+// The file is written atomically and recorded in the index of the output directory.
+func (c *Client) SaveOutput(day int, name string, v any) error {
+	enc, err := encoderFor(name)
 	if err != nil {
-		log.Println("Error getting working directory:", err)
+		return fmt.Errorf("save output %s: %w", name, err)
+	}
+
+	return c.WriteOutput(day, name, func(w io.Writer) error {
+		return enc.Encode(w, v)
+	})
+}
+
+// WriteOutput writes a file to days/XX/output in Dir, for formats that have no encoder.
+// The file is written atomically and recorded in the index of the output directory.
+func (c *Client) WriteOutput(day int, name string, write func(w io.Writer) error) error {
+	if err := Day(day).Validate(); err != nil {
+		return err
+	}
+	if name == "" || filepath.Base(name) != name || name == outputIndexFile {
+		return fmt.Errorf("invalid output name %q", name)
 	}
 
-	dir := filepath.Join(wd, "days", fmt.Sprintf("%02d", day), "output")
-	if err = os.MkdirAll(dir, 0755); err != nil {
-		log.Println("Error creating output directory:", err)
+	dir, err := c.outputDir(day)
+	if err != nil {
+		return err
 	}
 
-	fp := filepath.Join(dir, path)
-	log.Println("Creating file:", fp)
+	h := sha256.New()
+	var size int64
 
-	file, err := os.Create(fp)
+	err = writeFileAtomic(filepath.Join(dir, name), func(w io.Writer) error {
+		cw := &countingWriter{w: io.MultiWriter(w, h)}
+		err := write(cw)
+		size = cw.n
+		return err
+	})
 	if err != nil {
-		log.Println("Error creating file:", err)
+		return fmt.Errorf("save output %s: %w", name, err)
 	}
-	defer func() {
-		cerr := file.Close()
-		if err == nil {
-			err = cerr
-		}
-	}()
 
-	writerErr := writeFn(file)
-	if writerErr != nil {
-		log.Println("Error writing file:", writerErr)
+	return updateIndex(dir, Artifact{
+		Name:   name,
+		Format: strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), "."),
+		Size:   size,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	})
+}
+
+// Outputs returns the artifacts in the output directory of the given day, ordered by name.
+func (c *Client) Outputs(day int) ([]Artifact, error) {
+	dir, err := c.outputDir(day)
+	if err != nil {
+		return nil, err
+	}
+
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	return readIndex(dir)
+}
+
+func (c *Client) outputDir(day int) (string, error) {
+	dir, err := c.dir()
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(dir, fmt.Sprintf(outputDirFormat, day)), nil
+}
+
+// updateIndex adds the artifact to the index in dir, it replaces an earlier artifact with the same name.
+func updateIndex(dir string, a Artifact) error {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	artifacts, err := readIndex(dir)
+	if err != nil {
+		return err
+	}
+
+	i := sort.Search(len(artifacts), func(i int) bool {
+		return artifacts[i].Name >= a.Name
+	})
+	if i == len(artifacts) || artifacts[i].Name != a.Name {
+		artifacts = append(artifacts, Artifact{})
+		copy(artifacts[i+1:], artifacts[i:])
+	}
+	artifacts[i] = a
+
+	b, err := json.MarshalIndent(artifacts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return replaceFile(filepath.Join(dir, outputIndexFile), append(b, '\n'))
+}
+
+func readIndex(dir string) ([]Artifact, error) {
+	b, err := os.ReadFile(filepath.Join(dir, outputIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []Artifact
+	if err = json.Unmarshal(b, &artifacts); err != nil {
+		return nil, fmt.Errorf("read output index of %s: %w", dir, err)
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+
+	return artifacts, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func encodeText(w io.Writer, v any) error {
+	var err error
+	switch v := v.(type) {
+	case string:
+		_, err = io.WriteString(w, v)
+	case []byte:
+		_, err = w.Write(v)
+	case fmt.Stringer:
+		_, err = io.WriteString(w, v.String())
+	default:
+		err = fmt.Errorf("cannot encode %T as text, want a string, []byte or fmt.Stringer", v)
+	}
+	return err
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// encodeSVG draws the image as an SVG, with a rectangle per horizontal run of pixels of the same color.
+// Transparent pixels are left out.
+func encodeSVG(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	bw := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d" shape-rendering="crispEdges">`+"\n", b.Dx(), b.Dy())
+
+	at := func(x, y int) color.NRGBA {
+		return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; {
+			c, run := at(x, y), 1
+			for x+run < b.Max.X && at(x+run, y) == c {
+				run++
+			}
+
+			if c.A != 0 {
+				_, _ = fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="1" fill="#%02x%02x%02x"`, x-b.Min.X, y-b.Min.Y, run, c.R, c.G, c.B)
+				if c.A != 0xff {
+					_, _ = fmt.Fprintf(bw, ` fill-opacity="%.3f"`, float64(c.A)/0xff)
+				}
+				_, _ = bw.WriteString("/>\n")
+			}
+
+			x += run
+		}
+	}
+
+	_, _ = bw.WriteString("</svg>\n")
+	return bw.Flush()
 }
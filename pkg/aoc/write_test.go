@@ -0,0 +1,173 @@
+package aoc
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testStringer struct{}
+
+func (testStringer) String() string {
+	return "stringer\n"
+}
+
+func TestClient_SaveOutput(t *testing.T) {
+	c := &Client{Dir: t.TempDir()}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.NRGBA{R: 0xff, A: 0xff})
+	img.Set(1, 0, color.NRGBA{R: 0xff, A: 0xff})
+	img.Set(2, 0, color.NRGBA{B: 0xff, A: 0x80})
+
+	anim := &gif.GIF{
+		Image: []*image.Paletted{
+			image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black, color.White}),
+			image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black, color.White}),
+		},
+		Delay: []int{10, 10},
+	}
+
+	outputs := map[string]any{
+		"image.png":    img,
+		"image.svg":    img,
+		"anim.gif":     anim,
+		"floor.txt":    []byte("#.#\n"),
+		"string.txt":   "string\n",
+		"stringer.TXT": testStringer{},
+		"stats.json":   map[string]int{"steps": 13},
+	}
+
+	for name, v := range outputs {
+		if err := c.SaveOutput(9, name, v); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	dir := filepath.Join(c.Dir, "days", "09", "output")
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	if got, err := png.Decode(strings.NewReader(read("image.png"))); err != nil || got.Bounds() != img.Bounds() {
+		t.Errorf("got png %v, %v", got, err)
+	}
+	if got, err := gif.DecodeAll(strings.NewReader(read("anim.gif"))); err != nil || len(got.Image) != 2 {
+		t.Errorf("got gif %v, %v", got, err)
+	}
+
+	svg := read("image.svg")
+	for _, want := range []string{
+		`<rect x="0" y="0" width="2" height="1" fill="#ff0000"/>`,
+		`<rect x="2" y="0" width="1" height="1" fill="#0000ff" fill-opacity="0.502"/>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg does not contain %s:\n%s", want, svg)
+		}
+	}
+
+	texts := map[string]string{
+		"floor.txt":    "#.#\n",
+		"string.txt":   "string\n",
+		"stringer.TXT": "stringer\n",
+		"stats.json":   "{\n  \"steps\": 13\n}\n",
+	}
+	for name, want := range texts {
+		if got := read(name); got != want {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+
+	artifacts, err := c.Outputs(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, a := range artifacts {
+		names = append(names, a.Name)
+		if fi, err := os.Stat(filepath.Join(dir, a.Name)); err != nil || fi.Size() != a.Size {
+			t.Errorf("%s: size in index is %d, file: %v", a.Name, a.Size, err)
+		}
+	}
+	want := []string{"anim.gif", "floor.txt", "image.png", "image.svg", "stats.json", "string.txt", "stringer.TXT"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got artifacts %q, want %q", names, want)
+	}
+
+	// saving again replaces the artifact in the index
+	if err = c.SaveOutput(9, "floor.txt", "##\n"); err != nil {
+		t.Fatal(err)
+	}
+	if artifacts, err = c.Outputs(9); err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != len(want) || artifacts[1].Size != 3 || artifacts[1].Format != "txt" {
+		t.Errorf("got artifacts %+v after replacing floor.txt", artifacts)
+	}
+}
+
+func TestClient_SaveOutput_errors(t *testing.T) {
+	c := &Client{Dir: t.TempDir()}
+
+	if err := c.SaveOutput(9, "floor.txt", "#\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SaveOutput(9, "floor.bmp", "#\n"); !errors.Is(err, ErrNoEncoder) {
+		t.Errorf("got error %v, want %v", err, ErrNoEncoder)
+	}
+
+	for _, name := range []string{"", "../floor.txt", "index.json"} {
+		if err := c.SaveOutput(9, name, "#\n"); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+
+	if err := c.SaveOutput(9, "image.png", "not an image"); err == nil {
+		t.Error("expected an error for a value of the wrong type")
+	}
+
+	failed := errors.New("failed")
+	err := c.WriteOutput(9, "floor.txt", func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Errorf("got error %v, want %v", err, failed)
+	}
+
+	b, err := os.ReadFile(filepath.Join(c.Dir, "days", "09", "output", "floor.txt"))
+	if err != nil || string(b) != "#\n" {
+		t.Errorf("got %q, %v: a failed write should leave the file untouched", b, err)
+	}
+
+	artifacts, err := c.Outputs(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 1 || artifacts[0].Name != "floor.txt" {
+		t.Errorf("got artifacts %+v, want only floor.txt", artifacts)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(c.Dir, "days", "09", "output"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d files in the output directory, want floor.txt and the index", len(entries))
+	}
+}
@@ -0,0 +1,234 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a handle to an item of an IndexedLoop. It stays valid while the item moves,
+// so the current position of the item can be looked up with IndexedLoop.Index.
+type Node[Item any] struct {
+	Value Item
+
+	left, right, parent *Node[Item]
+	// prio is the random heap priority of the treap
+	prio uint64
+	// size is the number of nodes in the subtree
+	size int
+}
+
+// IndexedLoop is a loop of items backed by an implicit treap: a balanced tree ordered by position.
+// Unlike Loop, it finds, inserts, removes and moves items by position in O(log n).
+// Positions count from the first item, the last item is followed by the first.
+// The zero value for IndexedLoop is an empty loop ready to use.
+type IndexedLoop[Item any] struct {
+	root *Node[Item]
+	seed uint64
+}
+
+// NewIndexedLoop returns a loop with the given items, in order.
+func NewIndexedLoop[Item any](items ...Item) *IndexedLoop[Item] {
+	l := &IndexedLoop[Item]{}
+	for _, item := range items {
+		l.InsertAt(l.Size(), item)
+	}
+	return l
+}
+
+// Size returns the number of items in the loop.
+func (l *IndexedLoop[Item]) Size() int {
+	return size(l.root)
+}
+
+// At returns the node at position i. It panics when i is out of range.
+func (l *IndexedLoop[Item]) At(i int) *Node[Item] {
+	l.check(i, l.Size())
+
+	n := l.root
+	for {
+		switch left := size(n.left); {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// Index returns the current position of the node.
+func (l *IndexedLoop[Item]) Index(n *Node[Item]) int {
+	i := size(n.left)
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if n == p.right {
+			i += size(p.left) + 1
+		}
+	}
+	return i
+}
+
+// InsertAt inserts the item at position i, so the item at i and the items after it move up by one.
+// It returns the node of the item. It panics when i is out of range, i may be equal to Size.
+func (l *IndexedLoop[Item]) InsertAt(i int, item Item) *Node[Item] {
+	l.check(i, l.Size()+1)
+
+	n := &Node[Item]{Value: item, prio: l.random(), size: 1}
+	l.insert(i, n)
+	return n
+}
+
+// RemoveAt removes the item at position i and returns it. It panics when i is out of range.
+func (l *IndexedLoop[Item]) RemoveAt(i int) Item {
+	l.check(i, l.Size())
+
+	n := l.remove(i)
+	return n.Value
+}
+
+// Remove removes the node from the loop, the node must not be used afterwards.
+func (l *IndexedLoop[Item]) Remove(n *Node[Item]) {
+	l.remove(l.Index(n))
+}
+
+// Move moves the node by offset positions. The loop wraps around: the node is taken out, and
+// inserted offset positions further along the remaining items, backwards when offset is negative.
+func (l *IndexedLoop[Item]) Move(n *Node[Item], offset int) {
+	others := l.Size() - 1
+	if others <= 0 {
+		return
+	}
+
+	i := l.Index(n)
+	l.remove(i)
+
+	i = (i + offset%others) % others
+	if i < 0 {
+		i += others
+	}
+	l.insert(i, n)
+}
+
+// Each calls fn for every node in order, until fn returns false.
+// The loop must not be modified by fn.
+func (l *IndexedLoop[Item]) Each(fn func(i int, n *Node[Item]) bool) {
+	var i int
+	each(l.root, func(n *Node[Item]) bool {
+		ok := fn(i, n)
+		i++
+		return ok
+	})
+}
+
+func (l *IndexedLoop[Item]) String() string {
+	var sb strings.Builder
+	sb.WriteRune('[')
+	l.Each(func(i int, n *Node[Item]) bool {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("%v", n.Value))
+		return true
+	})
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+func (l *IndexedLoop[Item]) check(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("index [%d] out of range", i))
+	}
+}
+
+func (l *IndexedLoop[Item]) insert(i int, n *Node[Item]) {
+	left, right := split(l.root, i)
+	l.setRoot(merge(merge(left, n), right))
+}
+
+func (l *IndexedLoop[Item]) remove(i int) *Node[Item] {
+	left, right := split(l.root, i)
+	n, right := split(right, 1)
+	l.setRoot(merge(left, right))
+
+	n.parent = nil
+	return n
+}
+
+func (l *IndexedLoop[Item]) setRoot(n *Node[Item]) {
+	if n != nil {
+		n.parent = nil
+	}
+	l.root = n
+}
+
+// random returns the next number of the splitmix64 generator, which works from any seed.
+func (l *IndexedLoop[Item]) random() uint64 {
+	l.seed += 0x9e3779b97f4a7c15
+	z := l.seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func size[Item any](n *Node[Item]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update restores the size of the node and the parents of its children after a change of its children.
+func update[Item any](n *Node[Item]) {
+	n.size = 1 + size(n.left) + size(n.right)
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+}
+
+// split splits the tree into a tree with the first k nodes and a tree with the rest.
+func split[Item any](n *Node[Item], k int) (*Node[Item], *Node[Item]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if size(n.left) >= k {
+		left, rest := split(n.left, k)
+		n.left = rest
+		update(n)
+		return left, n
+	}
+
+	rest, right := split(n.right, k-size(n.left)-1)
+	n.right = rest
+	update(n)
+	return n, right
+}
+
+// merge joins two trees, the nodes of a come before the nodes of b.
+func merge[Item any](a, b *Node[Item]) *Node[Item] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.prio > b.prio:
+		a.right = merge(a.right, b)
+		update(a)
+		return a
+	}
+
+	b.left = merge(a, b.left)
+	update(b)
+	return b
+}
+
+func each[Item any](n *Node[Item], fn func(*Node[Item]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return each(n.left, fn) && fn(n) && each(n.right, fn)
+}
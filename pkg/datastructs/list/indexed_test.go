@@ -0,0 +1,161 @@
+package list
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestIndexedLoop_InsertAt(t *testing.T) {
+	var l IndexedLoop[int]
+	l.InsertAt(0, 2)
+	l.InsertAt(0, 1)
+	l.InsertAt(2, 4)
+	l.InsertAt(2, 3)
+
+	if got := l.String(); got != "[1, 2, 3, 4]" {
+		t.Errorf("expected [1, 2, 3, 4], got %s", got)
+	}
+
+	if got := l.RemoveAt(1); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+
+	if l.Size() != 3 || l.At(1).Value != 3 {
+		t.Errorf("expected [1, 3, 4], got %s", l.String())
+	}
+}
+
+func TestIndexedLoop_Move(t *testing.T) {
+	tests := []struct {
+		offset int
+		want   string
+	}{
+		{0, "[0, 1, 2, 3, 4]"},
+		{1, "[0, 2, 1, 3, 4]"},
+		// the position after the last item is the first one
+		{3, "[1, 0, 2, 3, 4]"},
+		// wraps around the 4 other items
+		{4, "[0, 1, 2, 3, 4]"},
+		{5, "[0, 2, 1, 3, 4]"},
+		{-1, "[1, 0, 2, 3, 4]"},
+		{-2, "[0, 2, 3, 1, 4]"},
+		{-9, "[1, 0, 2, 3, 4]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.offset), func(t *testing.T) {
+			l := NewIndexedLoop(0, 1, 2, 3, 4)
+			n := l.At(1)
+			l.Move(n, tt.offset)
+
+			if got := l.String(); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+			if l.At(l.Index(n)) != n {
+				t.Errorf("expected the node at its index %d", l.Index(n))
+			}
+		})
+	}
+}
+
+func TestIndexedLoop_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var l IndexedLoop[int]
+	var want []*Node[int]
+
+	for i := 0; i < 5000; i++ {
+		switch op := rng.Intn(4); {
+		case op < 2 || len(want) < 2:
+			at := rng.Intn(len(want) + 1)
+			n := l.InsertAt(at, i)
+			want = append(want[:at], append([]*Node[int]{n}, want[at:]...)...)
+		case op == 2:
+			at := rng.Intn(len(want))
+			if got := l.RemoveAt(at); got != want[at].Value {
+				t.Fatalf("remove at %d: expected %d, got %d", at, want[at].Value, got)
+			}
+			want = append(want[:at], want[at+1:]...)
+		default:
+			from, offset := rng.Intn(len(want)), rng.Intn(10000)-5000
+			n := want[from]
+			l.Move(n, offset)
+
+			want = append(want[:from], want[from+1:]...)
+			to := ((from+offset)%len(want) + len(want)) % len(want)
+			want = append(want[:to], append([]*Node[int]{n}, want[to:]...)...)
+		}
+
+		if l.Size() != len(want) {
+			t.Fatalf("step %d: expected size %d, got %d", i, len(want), l.Size())
+		}
+	}
+
+	for i, n := range want {
+		if got := l.Index(n); got != i {
+			t.Fatalf("expected node %d at %d, got %d", n.Value, i, got)
+		}
+		if l.At(i) != n {
+			t.Fatalf("expected node %d at %d, got %d", n.Value, i, l.At(i).Value)
+		}
+	}
+}
+
+func TestIndexedLoop_Each(t *testing.T) {
+	l := NewIndexedLoop("a", "b", "c")
+
+	var got []string
+	l.Each(func(i int, n *Node[string]) bool {
+		got = append(got, n.Value)
+		return i < 1
+	})
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
+
+// BenchmarkMix moves every item by its value, like the mixing of day 20.
+func BenchmarkMix(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]int, 5000)
+	for i := range values {
+		values[i] = rng.Intn(20000) - 10000
+	}
+
+	b.Run("Loop", func(b *testing.B) {
+		type item struct{ value, index int }
+
+		for i := 0; i < b.N; i++ {
+			items := make([]item, len(values))
+			for j, v := range values {
+				items[j] = item{v, j}
+			}
+			l := NewLoop(items...)
+
+			for j := range items {
+				for l.Value().index != j {
+					l.Next()
+				}
+				it := l.Remove()
+				l.Move(it.value % l.Size())
+				l.InsertBefore(it)
+			}
+		}
+	})
+
+	b.Run("IndexedLoop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			l := NewIndexedLoop[int]()
+			nodes := make([]*Node[int], len(values))
+			for j, v := range values {
+				nodes[j] = l.InsertAt(j, v)
+			}
+
+			for _, n := range nodes {
+				l.Move(n, n.Value)
+			}
+		}
+	})
+}
@@ -61,6 +61,7 @@ func (l *Loop[Item]) Prev() {
 	l.head = l.head.prev
 }
 
+// Move moves the head n nodes, one node at a time. IndexedLoop moves by position in O(log n).
 func (l *Loop[Item]) Move(n int) {
 	if n == 0 {
 		return
@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"math/bits"
+)
+
+// BucketMode selects how a Bucket queue stores its priorities.
+type BucketMode int
+
+// minBucketRing is the number of buckets of a Dial queue after its first insert.
+const minBucketRing = 8
+
+const (
+	// Dial keeps a bucket per priority, from the last popped priority up to the highest queued one.
+	// It is the fastest when the priorities are close together, such as the costs of a grid search.
+	// The buckets of the whole range are allocated, so a range of a million priorities takes a million
+	// buckets, use Radix when the priorities are far apart.
+	Dial BucketMode = iota
+	// Radix keeps a bucket per bit of the difference with the last popped priority, a radix heap.
+	// It uses 65 buckets regardless of the range of the priorities.
+	Radix
+)
+
+type bucketNode[Item any] struct {
+	Prio  int
+	Value Item
+}
+
+// Bucket is a monotone priority queue for integer priorities: it pops the item with the lowest priority,
+// and once an item is popped, items may not be inserted with a priority below the priority of the last
+// popped item. Before the first pop, any priority may be inserted, including negative ones.
+// Searches with non-negative costs and a consistent heuristic, such as Dijkstra and A*, meet this.
+// Equal priorities pop in reverse order of insertion in Dial mode, like Priority.
+// The zero value is an empty queue in Dial mode.
+type Bucket[Item any] struct {
+	mode BucketMode
+	// buckets is a ring that starts at head in Dial mode, its length is a power of two
+	buckets [][]bucketNode[Item]
+	head    int
+	// last is the priority of the last popped item, the priority of the first bucket in Dial mode.
+	// Before the first pop, it is the lowest inserted priority.
+	last   int
+	popped bool
+	n      int
+}
+
+// NewBucket returns an empty bucket queue in the given mode.
+func NewBucket[Item any](mode BucketMode) *Bucket[Item] {
+	return &Bucket[Item]{mode: mode}
+}
+
+// Insert adds the item with the priority. It panics when the priority is below the last popped priority.
+func (b *Bucket[Item]) Insert(item Item, priority int) {
+	if !b.popped && (b.n == 0 || priority < b.last) {
+		b.rebase(priority)
+	}
+	if priority < b.last {
+		panic("queue: priority below the last popped priority")
+	}
+
+	b.insert(bucketNode[Item]{Prio: priority, Value: item})
+}
+
+// Pop removes and returns the item with the lowest priority. It panics when the queue is empty.
+func (b *Bucket[Item]) Pop() Item {
+	if b.n == 0 {
+		panic("queue: pop from an empty queue")
+	}
+
+	if b.mode == Radix {
+		b.redistribute()
+	} else {
+		for len(b.buckets[b.head]) == 0 {
+			b.head = (b.head + 1) & (len(b.buckets) - 1)
+			b.last++
+		}
+	}
+
+	first := b.buckets[b.head]
+	top := first[len(first)-1]
+	first[len(first)-1] = bucketNode[Item]{}
+	b.buckets[b.head] = first[:len(first)-1]
+	b.n--
+	b.popped = true
+
+	return top.Value
+}
+
+// Len returns the number of queued items.
+func (b *Bucket[Item]) Len() int {
+	return b.n
+}
+
+func (b *Bucket[Item]) insert(node bucketNode[Item]) {
+	i := b.bucket(node.Prio)
+	b.buckets[i] = append(b.buckets[i], node)
+	b.n++
+}
+
+// bucket returns the index of the bucket of the priority, the buckets are grown to hold it.
+func (b *Bucket[Item]) bucket(priority int) int {
+	if b.mode == Radix {
+		i := bits.Len(uint(priority ^ b.last))
+		for len(b.buckets) <= i {
+			b.buckets = append(b.buckets, nil)
+		}
+		return i
+	}
+
+	d := priority - b.last
+	if d >= len(b.buckets) {
+		b.grow(d + 1)
+	}
+	return (b.head + d) & (len(b.buckets) - 1)
+}
+
+// grow doubles the ring of a Dial queue until it has at least n buckets.
+func (b *Bucket[Item]) grow(n int) {
+	size := len(b.buckets)
+	if size == 0 {
+		size = minBucketRing
+	}
+	for size < n {
+		size *= 2
+	}
+
+	buckets := make([][]bucketNode[Item], size)
+	for i := range b.buckets {
+		buckets[i] = b.buckets[(b.head+i)&(len(b.buckets)-1)]
+	}
+
+	b.buckets = buckets
+	b.head = 0
+}
+
+// rebase makes priority the priority of the first bucket, before the first pop. The queued items
+// are inserted again, items with equal priorities share a bucket and keep their order.
+func (b *Bucket[Item]) rebase(priority int) {
+	var nodes []bucketNode[Item]
+	for i, bucket := range b.buckets {
+		nodes = append(nodes, bucket...)
+		b.buckets[i] = nil
+	}
+
+	b.head, b.last, b.n = 0, priority, 0
+	for _, n := range nodes {
+		b.insert(n)
+	}
+}
+
+// redistribute makes sure the first bucket of a radix heap holds the items with the lowest priority,
+// by moving the items of the first non-empty bucket to lower buckets.
+func (b *Bucket[Item]) redistribute() {
+	if len(b.buckets[0]) > 0 {
+		return
+	}
+
+	i := 1
+	for len(b.buckets[i]) == 0 {
+		i++
+	}
+
+	nodes := b.buckets[i]
+	b.buckets[i] = nodes[:0]
+
+	b.last = nodes[0].Prio
+	for _, n := range nodes[1:] {
+		if n.Prio < b.last {
+			b.last = n.Prio
+		}
+	}
+
+	// every item moves to a lower bucket, because the items share the bits above bit i with the new last
+	for _, n := range nodes {
+		j := b.bucket(n.Prio)
+		b.buckets[j] = append(b.buckets[j], n)
+	}
+}
@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBucket_Pop(t *testing.T) {
+	var q Bucket[int]
+	q.Insert(1, 0)
+	q.Insert(2, 1)
+	q.Insert(3, 1)
+
+	// equal priorities pop in reverse order of insertion, like Priority
+	for _, want := range []int{1, 3, 2} {
+		if got := q.Pop(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("expected an empty queue, got %d items", q.Len())
+	}
+}
+
+func TestBucket_Insert(t *testing.T) {
+	q := NewBucket[int](Dial)
+	q.Insert(1, 5)
+	q.Pop()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on a priority below the last popped priority")
+		}
+	}()
+	q.Insert(2, 4)
+}
+
+func TestBucket_negative(t *testing.T) {
+	for name, mode := range map[string]BucketMode{"dial": Dial, "radix": Radix} {
+		mode := mode
+		t.Run(name, func(t *testing.T) {
+			q := NewBucket[int](mode)
+			for _, prio := range []int{0, 2, -3, -1, 2} {
+				q.Insert(prio, prio)
+			}
+
+			for _, want := range []int{-3, -1, 0, 2, 2} {
+				if got := q.Pop(); got != want {
+					t.Errorf("expected %d, got %d", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBucket_ring(t *testing.T) {
+	q := NewBucket[int](Dial)
+	for i := 0; i < 5; i++ {
+		q.Insert(i, 1000+i)
+	}
+
+	// the buckets of popped priorities are reused for higher ones
+	for i := 5; i < 10000; i++ {
+		q.Insert(i, 1000+i)
+		if got := q.Pop(); got != i-5 {
+			t.Fatalf("expected %d, got %d", i-5, got)
+		}
+	}
+
+	if len(q.buckets) != minBucketRing {
+		t.Errorf("expected %d buckets, got %d", minBucketRing, len(q.buckets))
+	}
+}
+
+func TestBucket_monotone(t *testing.T) {
+	for name, mode := range map[string]BucketMode{"dial": Dial, "radix": Radix} {
+		mode := mode
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			q := NewBucket[int](mode)
+
+			prios := make(map[int]int)
+			insert := func(prio int) {
+				item := len(prios)
+				prios[item] = prio
+				q.Insert(item, prio)
+			}
+
+			insert(0)
+
+			var last, pops int
+			for q.Len() > 0 {
+				item := q.Pop()
+				pops++
+
+				if prios[item] < last {
+					t.Fatalf("pop %d: priority %d below the last priority %d", pops, prios[item], last)
+				}
+				last = prios[item]
+
+				if len(prios) > 10000 {
+					continue
+				}
+				for i := rng.Intn(4); i > 0; i-- {
+					insert(last + rng.Intn(1000))
+				}
+			}
+
+			if pops != len(prios) {
+				t.Errorf("expected %d pops, got %d", len(prios), pops)
+			}
+		})
+	}
+}
+
+type intQueue interface {
+	Insert(item, priority int)
+	Pop() int
+	Len() int
+}
+
+// benchmarkMonotone is a Dijkstra-like workload: pop the first item and push successors with a small extra cost.
+func benchmarkMonotone(b *testing.B, maxCost int, newQueue func() intQueue) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < b.N; i++ {
+		q := newQueue()
+		prios := make([]int, 1, 20000)
+		q.Insert(0, 0)
+
+		for q.Len() > 0 {
+			item := q.Pop()
+			if len(prios) > 15000 {
+				continue
+			}
+			for j := rng.Intn(4); j > 0; j-- {
+				prio := prios[item] + 1 + rng.Intn(maxCost)
+				q.Insert(len(prios), prio)
+				prios = append(prios, prio)
+			}
+		}
+	}
+}
+
+func BenchmarkBucket(b *testing.B) {
+	queues := []struct {
+		name string
+		new  func() intQueue
+	}{
+		{"heap", func() intQueue { return NewHeap[int, int](Min) }},
+		{"dial", func() intQueue { return NewBucket[int](Dial) }},
+		{"radix", func() intQueue { return NewBucket[int](Radix) }},
+	}
+
+	for _, maxCost := range []int{2, 1000} {
+		for _, q := range queues {
+			b.Run(fmt.Sprintf("cost%d/%s", maxCost, q.name), func(b *testing.B) {
+				benchmarkMonotone(b, maxCost, q.new)
+			})
+		}
+	}
+}
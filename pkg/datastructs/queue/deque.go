@@ -0,0 +1,148 @@
+package queue
+
+import (
+	"fmt"
+)
+
+// minDequeCap is the capacity of a deque after its first push.
+const minDequeCap = 8
+
+// Deque is a double-ended queue backed by a ring buffer that grows when it is full.
+// Pushing and popping at either end take amortized O(1), so PushBack with PopFront is a FIFO queue
+// that does not leak the front of a slice, and PushBack with PopBack is a stack.
+// The zero value for Deque is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewDeque returns a deque with the given items, from front to back.
+func NewDeque[T any](items ...T) *Deque[T] {
+	d := &Deque[T]{}
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return d
+}
+
+// Len returns the number of items in the deque.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// PushBack adds the item at the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.buf[d.index(d.size)] = item
+	d.size++
+}
+
+// PushFront adds the item at the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = item
+	d.size++
+}
+
+// PopFront removes and returns the item at the front of the deque. It panics when the deque is empty.
+func (d *Deque[T]) PopFront() T {
+	if d.size == 0 {
+		panic("queue: pop from an empty deque")
+	}
+
+	var zero T
+	item := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	return item
+}
+
+// PopBack removes and returns the item at the back of the deque. It panics when the deque is empty.
+func (d *Deque[T]) PopBack() T {
+	if d.size == 0 {
+		panic("queue: pop from an empty deque")
+	}
+
+	var zero T
+	i := d.index(d.size - 1)
+	item := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	return item
+}
+
+// Front returns the item at the front of the deque. It panics when the deque is empty.
+func (d *Deque[T]) Front() T {
+	return d.At(0)
+}
+
+// Back returns the item at the back of the deque. It panics when the deque is empty.
+func (d *Deque[T]) Back() T {
+	return d.At(d.size - 1)
+}
+
+// At returns the item at index i, counting from the front. It panics when i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.size {
+		panic(fmt.Sprintf("index [%d] out of range", i))
+	}
+	return d.buf[d.index(i)]
+}
+
+// Each calls fn for every item from front to back, until fn returns false.
+// The deque must not be modified by fn.
+func (d *Deque[T]) Each(fn func(i int, item T) bool) {
+	for i := 0; i < d.size; i++ {
+		if !fn(i, d.buf[d.index(i)]) {
+			return
+		}
+	}
+}
+
+// Clear removes all items, the buffer is kept.
+func (d *Deque[T]) Clear() {
+	var zero T
+	for i := 0; i < d.size; i++ {
+		d.buf[d.index(i)] = zero
+	}
+	d.head, d.size = 0, 0
+}
+
+// String returns the items from front to back.
+func (d *Deque[T]) String() string {
+	items := make([]T, 0, d.size)
+	d.Each(func(_ int, item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return fmt.Sprint(items)
+}
+
+// index returns the position in the buffer of the item at index i, counting from the front.
+func (d *Deque[T]) index(i int) int {
+	// the capacity is a power of two
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow doubles the buffer when it is full.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+
+	n := 2 * len(d.buf)
+	if n == 0 {
+		n = minDequeCap
+	}
+
+	buf := make([]T, n)
+	// copy the items in order, they may wrap around the end of the old buffer
+	copied := copy(buf, d.buf[d.head:])
+	copy(buf[copied:], d.buf[:d.head])
+
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,149 @@
+package queue
+
+import (
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+
+	// wrap around the end of the buffer and grow while wrapped
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+
+	want := "[19 17 15 13 11 9 7 5 3 1 0 2 4 6 8 10 12 14 16 18]"
+	if got := d.String(); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	if d.Len() != 20 || d.Front() != 19 || d.Back() != 18 || d.At(10) != 0 {
+		t.Errorf("expected 20 items from 19 to 18 with 0 at 10, got %d items: %s", d.Len(), d.String())
+	}
+
+	for _, want := range []int{19, 17, 15} {
+		if got := d.PopFront(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+
+	for _, want := range []int{18, 16, 14} {
+		if got := d.PopBack(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+
+	if d.Len() != 14 {
+		t.Errorf("expected 14 items, got %d", d.Len())
+	}
+}
+
+func TestDeque_fifo(t *testing.T) {
+	d := NewDeque(1, 2, 3)
+
+	for i := 4; i < 100; i++ {
+		d.PushBack(i)
+		if got := d.PopFront(); got != i-3 {
+			t.Fatalf("expected %d, got %d", i-3, got)
+		}
+	}
+
+	// the buffer does not grow beyond the number of queued items
+	if len(d.buf) != minDequeCap {
+		t.Errorf("expected a buffer of %d, got %d", minDequeCap, len(d.buf))
+	}
+}
+
+func TestDeque_Each(t *testing.T) {
+	d := NewDeque("a", "b", "c")
+
+	var got []string
+	d.Each(func(i int, item string) bool {
+		got = append(got, item)
+		return i < 1
+	})
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
+
+func TestDeque_Clear(t *testing.T) {
+	d := NewDeque(1, 2, 3)
+	d.Clear()
+
+	if d.Len() != 0 {
+		t.Errorf("expected an empty deque, got %s", d.String())
+	}
+
+	d.PushFront(4)
+	if d.Front() != 4 || d.Back() != 4 {
+		t.Errorf("expected [4], got %s", d.String())
+	}
+}
+
+func TestDeque_At(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on an index out of range")
+		}
+	}()
+
+	var d Deque[int]
+	d.At(0)
+}
+
+// benchmarkFIFO is a BFS-like workload: pop the front and push a few items at the back,
+// until 1000 items have been pushed for every iteration.
+func benchmarkFIFO(b *testing.B, push func(int), pop func() int, size func() int) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		push(0)
+		pushed := 1
+		for size() > 0 {
+			item := pop()
+			for j := 0; j < 3 && pushed < 1000; j++ {
+				push(item + j)
+				pushed++
+			}
+		}
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	b.Run("deque", func(b *testing.B) {
+		var d Deque[int]
+		benchmarkFIFO(b, d.PushBack, d.PopFront, d.Len)
+	})
+
+	// re-slicing the front leaks the popped items until append copies the slice
+	b.Run("slice", func(b *testing.B) {
+		var s []int
+		benchmarkFIFO(b,
+			func(item int) { s = append(s, item) },
+			func() int {
+				item := s[0]
+				s = s[1:]
+				return item
+			},
+			func() int { return len(s) },
+		)
+	})
+
+	b.Run("slice-copy", func(b *testing.B) {
+		var s []int
+		benchmarkFIFO(b,
+			func(item int) { s = append(s, item) },
+			func() int {
+				item := s[0]
+				s = s[:copy(s, s[1:])]
+				return item
+			},
+			func() int { return len(s) },
+		)
+	})
+}
@@ -0,0 +1,187 @@
+package queue
+
+// Ordered is the set of types that support the < operator.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Order is the order in which a Heap pops its items.
+type Order int
+
+const (
+	// Min pops the item with the lowest priority first.
+	Min Order = iota
+	// Max pops the item with the highest priority first.
+	Max
+)
+
+type heapNode[Item comparable, P any] struct {
+	Prio  P
+	Value Item
+	// seq orders nodes of equal priority, the last inserted node pops first, like Priority
+	seq uint64
+}
+
+// Heap is a priority queue backed by a binary heap. Insert, Pop and Upsert take O(log n),
+// Contains takes O(1) because the heap keeps the position of every item.
+// An item is queued at most once. Priorities may be of any type that has a less function,
+// such as tuples that are compared lexicographically.
+type Heap[Item comparable, P any] struct {
+	less  func(a, b P) bool
+	nodes []heapNode[Item, P]
+	index map[Item]int
+	seq   uint64
+}
+
+// NewHeap returns an empty heap that pops items in the given order of their priorities.
+func NewHeap[Item comparable, P Ordered](order Order) *Heap[Item, P] {
+	if order == Max {
+		return NewHeapFunc[Item](func(a, b P) bool { return a > b })
+	}
+	return NewHeapFunc[Item](func(a, b P) bool { return a < b })
+}
+
+// NewHeapFunc returns an empty heap that pops the item with the lowest priority according to less first.
+func NewHeapFunc[Item comparable, P any](less func(a, b P) bool) *Heap[Item, P] {
+	return &Heap[Item, P]{less: less}
+}
+
+// Insert adds the item with the priority, an item that is already queued gets the new priority.
+func (h *Heap[Item, P]) Insert(item Item, priority P) {
+	h.Upsert(item, priority)
+}
+
+// Upsert sets the priority of the item, adding it when it is not queued.
+func (h *Heap[Item, P]) Upsert(item Item, priority P) {
+	if h.index == nil {
+		h.index = make(map[Item]int)
+	}
+
+	h.seq++
+	n := heapNode[Item, P]{Prio: priority, Value: item, seq: h.seq}
+
+	i, ok := h.index[item]
+	if !ok {
+		i = len(h.nodes)
+		h.nodes = append(h.nodes, n)
+		h.index[item] = i
+		h.up(i)
+		return
+	}
+
+	h.nodes[i] = n
+	if !h.up(i) {
+		h.down(i)
+	}
+}
+
+// Pop removes and returns the first item. It panics when the heap is empty.
+func (h *Heap[Item, P]) Pop() Item {
+	top := h.nodes[0].Value
+	h.remove(0)
+	return top
+}
+
+// Peek returns the first item and its priority without removing it. It panics when the heap is empty.
+func (h *Heap[Item, P]) Peek() (Item, P) {
+	return h.nodes[0].Value, h.nodes[0].Prio
+}
+
+// Len returns the number of queued items.
+func (h *Heap[Item, P]) Len() int {
+	return len(h.nodes)
+}
+
+// Contains reports whether the item is queued.
+func (h *Heap[Item, P]) Contains(item Item) bool {
+	_, ok := h.index[item]
+	return ok
+}
+
+// Priority returns the priority of a queued item.
+func (h *Heap[Item, P]) Priority(item Item) (P, bool) {
+	i, ok := h.index[item]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return h.nodes[i].Prio, true
+}
+
+// Remove removes the item from the heap, it reports whether the item was queued.
+func (h *Heap[Item, P]) Remove(item Item) bool {
+	i, ok := h.index[item]
+	if ok {
+		h.remove(i)
+	}
+	return ok
+}
+
+func (h *Heap[Item, P]) remove(i int) {
+	last := len(h.nodes) - 1
+	delete(h.index, h.nodes[i].Value)
+
+	if i != last {
+		h.nodes[i] = h.nodes[last]
+		h.index[h.nodes[i].Value] = i
+	}
+	h.nodes[last] = heapNode[Item, P]{}
+	h.nodes = h.nodes[:last]
+
+	if i != last && !h.up(i) {
+		h.down(i)
+	}
+}
+
+// before reports whether node a pops before node b.
+func (h *Heap[Item, P]) before(a, b int) bool {
+	na, nb := h.nodes[a], h.nodes[b]
+	switch {
+	case h.less(na.Prio, nb.Prio):
+		return true
+	case h.less(nb.Prio, na.Prio):
+		return false
+	}
+	return na.seq > nb.seq
+}
+
+func (h *Heap[Item, P]) swap(a, b int) {
+	h.nodes[a], h.nodes[b] = h.nodes[b], h.nodes[a]
+	h.index[h.nodes[a].Value] = a
+	h.index[h.nodes[b].Value] = b
+}
+
+// up moves the node at i towards the root, it reports whether the node moved.
+func (h *Heap[Item, P]) up(i int) bool {
+	start := i
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.before(i, parent) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+// down moves the node at i towards the leaves.
+func (h *Heap[Item, P]) down(i int) {
+	for {
+		first := i
+		left, right := 2*i+1, 2*i+2
+		if left < len(h.nodes) && h.before(left, first) {
+			first = left
+		}
+		if right < len(h.nodes) && h.before(right, first) {
+			first = right
+		}
+		if first == i {
+			return
+		}
+		h.swap(i, first)
+		i = first
+	}
+}
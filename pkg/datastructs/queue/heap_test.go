@@ -0,0 +1,191 @@
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHeap_Pop(t *testing.T) {
+	h := NewHeap[int, int](Min)
+	h.Insert(1, 0)
+	h.Insert(2, 1)
+	h.Insert(3, 1)
+
+	// equal priorities pop in reverse order of insertion, like Priority
+	for _, want := range []int{1, 3, 2} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+
+	if h.Len() != 0 {
+		t.Errorf("expected an empty heap, got %d items", h.Len())
+	}
+}
+
+func TestHeap_Upsert(t *testing.T) {
+	h := NewHeap[int, int](Min)
+	for i := 1; i <= 5; i++ {
+		h.Insert(i, 10)
+	}
+	h.Upsert(3, 0)
+	h.Upsert(2, 0)
+	h.Upsert(5, 20)
+
+	if h.Len() != 5 {
+		t.Fatalf("expected 5 items, got %d", h.Len())
+	}
+
+	if p, ok := h.Priority(5); !ok || p != 20 {
+		t.Errorf("expected priority 20, got %d (%t)", p, ok)
+	}
+
+	for _, want := range []int{2, 3, 4, 1, 5} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestHeap_Max(t *testing.T) {
+	h := NewHeap[string, int](Max)
+	h.Insert("b", 2)
+	h.Insert("c", 3)
+	h.Insert("a", 1)
+
+	if item, prio := h.Peek(); item != "c" || prio != 3 {
+		t.Errorf("expected c with priority 3, got %s with %d", item, prio)
+	}
+
+	for _, want := range []string{"c", "b", "a"} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestNewHeapFunc(t *testing.T) {
+	// lexicographic priorities, such as (minute, distance)
+	type prio struct {
+		minute, distance int
+	}
+
+	h := NewHeapFunc[string](func(a, b prio) bool {
+		if a.minute != b.minute {
+			return a.minute < b.minute
+		}
+		return a.distance < b.distance
+	})
+	h.Insert("c", prio{2, 0})
+	h.Insert("b", prio{1, 5})
+	h.Insert("a", prio{1, 3})
+	h.Insert("d", prio{2, 1})
+
+	for _, want := range []string{"a", "b", "c", "d"} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestHeap_float(t *testing.T) {
+	h := NewHeap[int, float64](Min)
+	h.Insert(1, 1.5)
+	h.Insert(2, 0.25)
+	h.Upsert(1, 0.125)
+
+	for _, want := range []int{1, 2} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestHeap_Remove(t *testing.T) {
+	h := NewHeap[int, int](Min)
+	for i := 0; i < 10; i++ {
+		h.Insert(i, i)
+	}
+
+	if !h.Remove(4) || h.Remove(4) {
+		t.Error("expected to remove 4 once")
+	}
+	if h.Contains(4) {
+		t.Error("expected 4 to be removed")
+	}
+
+	for _, want := range []int{0, 1, 2, 3, 5, 6, 7, 8, 9} {
+		if got := h.Pop(); got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestHeap_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	h := NewHeap[int, int](Min)
+	prios := make(map[int]int)
+
+	for i := 0; i < 1000; i++ {
+		item, prio := rng.Intn(200), rng.Intn(100)
+		h.Upsert(item, prio)
+		prios[item] = prio
+	}
+
+	want := make([]int, 0, len(prios))
+	for _, p := range prios {
+		want = append(want, p)
+	}
+	sort.Ints(want)
+
+	for i, p := range want {
+		_, got := h.Peek()
+		item := h.Pop()
+		if got != p || prios[item] != p {
+			t.Fatalf("pop %d: expected priority %d, got %d", i, p, got)
+		}
+	}
+}
+
+// benchmarkOps is a search-like workload: pop the first item and push a few successors,
+// some of which are already queued.
+func benchmarkOps(b *testing.B, insert func(item, prio int), upsert func(item, prio int), pop func() int, size func() int) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < b.N; i++ {
+		insert(0, 0)
+		next := 1
+		for steps := 0; size() > 0 && steps < 5000; steps++ {
+			pop()
+			for j := 0; j < 3; j++ {
+				if rng.Intn(4) == 0 && next > 1 {
+					upsert(rng.Intn(next), steps+rng.Intn(10))
+					continue
+				}
+				insert(next, steps+rng.Intn(10))
+				next++
+			}
+		}
+		for size() > 0 {
+			pop()
+		}
+	}
+}
+
+func BenchmarkPriority(b *testing.B) {
+	q := NewPriority[item[int]]()
+	benchmarkOps(b,
+		func(i, p int) { q.Insert(item[int]{i}, p) },
+		func(i, p int) { q.Upsert(item[int]{i}, p) },
+		func() int { return q.Pop().t },
+		// Len has a value receiver, so the method value would copy the queue
+		func() int { return q.Len() },
+	)
+}
+
+func BenchmarkHeap(b *testing.B) {
+	h := NewHeap[int, int](Min)
+	benchmarkOps(b, h.Insert, h.Upsert, h.Pop, h.Len)
+}
@@ -15,6 +15,7 @@ type node[Item Equatable[Item]] struct {
 
 // Priority[Item any] is a priorityqueue
 // Item may be anything (the item type), the priority must be comparable
+// Heap is faster, takes comparable items and supports other priority types.
 type Priority[Item Equatable[Item]] struct {
 	nodes []node[Item]
 }
@@ -0,0 +1,159 @@
+// Package render turns the state of simulations into images and animations.
+package render
+
+import (
+	"fmt"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+)
+
+// DefaultDelay is the default time a frame is shown, in 100ths of a second.
+const DefaultDelay = 10
+
+// Recorder collects the frames of a simulation and encodes them as an animated GIF.
+// The zero value records every frame with the Plan 9 palette.
+type Recorder struct {
+	// Palette is the palette of the frames, colors are mapped to the closest color in it.
+	Palette color.Palette
+	// Delay is the time a frame is shown, in 100ths of a second. Zero is DefaultDelay.
+	Delay int
+	// Hold is the time the last frame is shown, in 100ths of a second. Zero is Delay.
+	Hold int
+	// Skip records only every Skip-th frame that is added, the first frame is always recorded.
+	Skip int
+	// MaxWidth and MaxHeight limit the size of the frames, larger frames are downscaled
+	// by a whole factor so pixels stay sharp. Zero means no limit.
+	MaxWidth, MaxHeight int
+
+	added  int
+	frames []*image.Paletted
+	// skipped renders the last added frame when it was skipped, for Flush
+	skipped func() image.Image
+}
+
+// Add records the image as the next frame, unless it is skipped. A recorded image is copied,
+// so the caller can reuse it.
+func (r *Recorder) Add(img image.Image) {
+	r.AddFunc(func() image.Image {
+		return img
+	})
+}
+
+// AddFunc records the image returned by render as the next frame. Render is only called when the
+// frame is not skipped, so expensive renderings are only made for the recorded frames.
+func (r *Recorder) AddFunc(render func() image.Image) {
+	n := r.added
+	r.added++
+
+	if r.Skip > 1 && n%r.Skip != 0 {
+		r.skipped = render
+		return
+	}
+
+	r.skipped = nil
+	r.frames = append(r.frames, r.paletted(render()))
+}
+
+// Flush records the last added frame when it was skipped, so the animation ends with the final state
+// of the simulation. Its render function is called now, an image given to Add must not have changed.
+func (r *Recorder) Flush() {
+	if r.skipped == nil {
+		return
+	}
+
+	r.frames = append(r.frames, r.paletted(r.skipped()))
+	r.skipped = nil
+}
+
+// Len returns the number of recorded frames.
+func (r *Recorder) Len() int {
+	return len(r.frames)
+}
+
+// GIF returns the recorded frames as an animation that loops forever.
+func (r *Recorder) GIF() *gif.GIF {
+	g := &gif.GIF{
+		Image:    r.frames,
+		Delay:    make([]int, len(r.frames)),
+		Disposal: make([]byte, len(r.frames)),
+		Config:   image.Config{ColorModel: r.palette()},
+	}
+
+	delay := r.Delay
+	if delay <= 0 {
+		delay = DefaultDelay
+	}
+
+	for i, frame := range r.frames {
+		g.Delay[i] = delay
+		g.Disposal[i] = gif.DisposalNone
+		g.Config.Width = max(g.Config.Width, frame.Rect.Max.X)
+		g.Config.Height = max(g.Config.Height, frame.Rect.Max.Y)
+	}
+
+	if n := len(g.Delay); n > 0 && r.Hold > 0 {
+		g.Delay[n-1] = r.Hold
+	}
+
+	return g
+}
+
+// Save flushes the recorder and writes the animation to the output directory of the day of the client.
+func (r *Recorder) Save(c *aoc.Client, day int, name string) error {
+	r.Flush()
+	if len(r.frames) == 0 {
+		return fmt.Errorf("save %s: no frames recorded", name)
+	}
+	return c.SaveOutput(day, name, r.GIF())
+}
+
+func (r *Recorder) palette() color.Palette {
+	if len(r.Palette) == 0 {
+		return palette.Plan9
+	}
+	return r.Palette
+}
+
+// paletted converts the image to a frame, downscaled to the maximum size.
+func (r *Recorder) paletted(img image.Image) *image.Paletted {
+	b := img.Bounds()
+
+	scale := 1
+	if r.MaxWidth > 0 {
+		scale = max(scale, ceilDiv(b.Dx(), r.MaxWidth))
+	}
+	if r.MaxHeight > 0 {
+		scale = max(scale, ceilDiv(b.Dy(), r.MaxHeight))
+	}
+
+	frame := image.NewPaletted(image.Rect(0, 0, ceilDiv(b.Dx(), scale), ceilDiv(b.Dy(), scale)), r.palette())
+
+	if scale == 1 {
+		draw.Draw(frame, frame.Rect, img, b.Min, draw.Src)
+		return frame
+	}
+
+	// nearest neighbour, every pixel of the frame is the top left pixel of its block
+	for y := 0; y < frame.Rect.Dy(); y++ {
+		for x := 0; x < frame.Rect.Dx(); x++ {
+			frame.Set(x, y, img.At(b.Min.X+x*scale, b.Min.Y+y*scale))
+		}
+	}
+
+	return frame
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
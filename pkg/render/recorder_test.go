@@ -0,0 +1,163 @@
+package render
+
+import (
+	"bytes"
+	"github.com/pimvanhespen/aoc2022/pkg/aoc"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var (
+	black = color.RGBA{A: 0xff}
+	white = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+func uniform(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestRecorder_skip(t *testing.T) {
+	rec := Recorder{Palette: color.Palette{black, white}, Skip: 3, Delay: 5, Hold: 100}
+
+	var rendered int
+	for i := 0; i < 7; i++ {
+		rec.AddFunc(func() image.Image {
+			rendered++
+			return uniform(2, 2, white)
+		})
+	}
+
+	// frames 0, 3 and 6 are recorded
+	if rec.Len() != 3 || rendered != 3 {
+		t.Fatalf("got %d frames and %d renderings, want 3", rec.Len(), rendered)
+	}
+
+	g := rec.GIF()
+	if want := []int{5, 5, 100}; len(g.Delay) != 3 || g.Delay[0] != want[0] || g.Delay[2] != want[2] {
+		t.Errorf("got delays %v, want %v", g.Delay, want)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Image) != 3 || decoded.Config.Width != 2 || decoded.Config.Height != 2 {
+		t.Errorf("got %d frames of %dx%d", len(decoded.Image), decoded.Config.Width, decoded.Config.Height)
+	}
+	if got := decoded.Image[0].At(1, 1); !sameColor(got, white) {
+		t.Errorf("got color %v, want %v", got, white)
+	}
+}
+
+func TestRecorder_downscale(t *testing.T) {
+	rec := Recorder{Palette: color.Palette{black, white}, MaxWidth: 4, MaxHeight: 10}
+
+	// stripes of 3 pixels wide are scaled down by 3 to 4x1
+	img := image.NewRGBA(image.Rect(5, 5, 15, 8))
+	for y := 5; y < 8; y++ {
+		for x := 5; x < 15; x++ {
+			c := black
+			if (x-5)/3%2 == 1 {
+				c = white
+			}
+			img.Set(x, y, c)
+		}
+	}
+	rec.Add(img)
+
+	frame := rec.GIF().Image[0]
+	if got := frame.Bounds(); got != image.Rect(0, 0, 4, 1) {
+		t.Fatalf("got bounds %v, want (0,0)-(4,1)", got)
+	}
+
+	want := []color.Color{black, white, black, white}
+	for x, c := range want {
+		if got := frame.At(x, 0); !sameColor(got, c) {
+			t.Errorf("pixel %d: got %v, want %v", x, got, c)
+		}
+	}
+}
+
+func TestRecorder_zero(t *testing.T) {
+	var rec Recorder
+	rec.Add(uniform(3, 2, color.RGBA{R: 0xff, A: 0xff}))
+
+	g := rec.GIF()
+	if len(g.Image) != 1 || g.Delay[0] != DefaultDelay || g.Config.Width != 3 || g.Config.Height != 2 {
+		t.Errorf("got %d frames, delays %v and size %dx%d", len(g.Image), g.Delay, g.Config.Width, g.Config.Height)
+	}
+}
+
+func TestRecorder_Flush(t *testing.T) {
+	rec := Recorder{Palette: color.Palette{black, white}, Skip: 10}
+
+	for i := 0; i < 12; i++ {
+		c := black
+		if i == 11 {
+			c = white
+		}
+		rec.Add(uniform(1, 1, c))
+	}
+
+	// frames 0 and 10 are recorded, the final frame 11 only by Flush
+	if rec.Len() != 2 {
+		t.Fatalf("got %d frames, want 2", rec.Len())
+	}
+
+	rec.Flush()
+	rec.Flush()
+
+	if rec.Len() != 3 {
+		t.Fatalf("got %d frames after flushing, want 3", rec.Len())
+	}
+	if got := rec.GIF().Image[2].At(0, 0); !sameColor(got, white) {
+		t.Errorf("got final color %v, want %v", got, white)
+	}
+
+	// nothing to flush when the last frame was recorded
+	rec = Recorder{Skip: 2}
+	rec.Add(uniform(1, 1, white))
+	rec.Flush()
+	if rec.Len() != 1 {
+		t.Errorf("got %d frames, want 1", rec.Len())
+	}
+}
+
+func TestRecorder_Save(t *testing.T) {
+	c := &aoc.Client{Dir: t.TempDir()}
+
+	var rec Recorder
+	if err := rec.Save(c, 14, "empty.gif"); err == nil {
+		t.Error("expected an error when there are no frames")
+	}
+
+	rec.Add(uniform(2, 2, white))
+	if err := rec.Save(c, 14, "sand.gif"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(c.Dir, "days", "14", "output", "sand.gif")); err != nil {
+		t.Error(err)
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
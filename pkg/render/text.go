@@ -0,0 +1,75 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Text draws text renderings of a grid, such as the String methods of the days, as images.
+// Every character is drawn as a square cell of its color.
+type Text struct {
+	// Colors are the colors of the characters, characters without a color are drawn in the background color.
+	Colors map[rune]color.Color
+	// Background is the color of the background, nil is black.
+	Background color.Color
+	// Cell is the size of a cell in pixels, zero is one pixel.
+	Cell int
+}
+
+// Image draws the text, lines are separated by newlines.
+func (t Text) Image(s string) image.Image {
+	cell := t.Cell
+	if cell <= 0 {
+		cell = 1
+	}
+
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+
+	var width int
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, width*cell, len(lines)*cell), t.Palette())
+
+	for y, line := range lines {
+		var x int
+		for _, r := range line {
+			if c, ok := t.Colors[r]; ok {
+				rect := image.Rect(x*cell, y*cell, (x+1)*cell, (y+1)*cell)
+				draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+			}
+			x++
+		}
+	}
+
+	return img
+}
+
+// Palette returns the background color followed by the colors of the characters, ordered by character,
+// so a Recorder can use the exact colors of the text.
+func (t Text) Palette() color.Palette {
+	bg := t.Background
+	if bg == nil {
+		bg = color.Black
+	}
+
+	runes := make([]rune, 0, len(t.Colors))
+	for r := range t.Colors {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+
+	p := color.Palette{bg}
+	for _, r := range runes {
+		p = append(p, t.Colors[r])
+	}
+
+	return p
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestText_Image(t *testing.T) {
+	rock := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	sand := color.RGBA{R: 0xe0, G: 0xc0, B: 0x60, A: 0xff}
+
+	text := Text{
+		Colors: map[rune]color.Color{'#': rock, 'o': sand},
+		Cell:   2,
+	}
+
+	img := text.Image("#.o\n##\n")
+
+	if got := img.Bounds(); got != image.Rect(0, 0, 6, 4) {
+		t.Fatalf("got bounds %v, want (0,0)-(6,4)", got)
+	}
+
+	tests := []struct {
+		x, y int
+		want color.Color
+	}{
+		{0, 0, rock},
+		{1, 1, rock},
+		{2, 0, color.Black},
+		{5, 1, sand},
+		{3, 3, rock},
+		// the second line is shorter
+		{4, 2, color.Black},
+	}
+
+	for _, tc := range tests {
+		if got := img.At(tc.x, tc.y); !sameColor(got, tc.want) {
+			t.Errorf("(%d,%d): got %v, want %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestText_Palette(t *testing.T) {
+	text := Text{
+		Colors:     map[rune]color.Color{'o': white, '#': black},
+		Background: color.Transparent,
+	}
+
+	p := text.Palette()
+	if len(p) != 3 || !sameColor(p[0], color.Transparent) || !sameColor(p[1], black) || !sameColor(p[2], white) {
+		t.Errorf("got palette %v", p)
+	}
+}